@@ -4,21 +4,134 @@ package graph
 type SearchAlgorithm int
 
 const (
-	// Depth-First Search
+	// Depth-First Search, vertex is returned after all vertexes reachable from it (post-order)
 	DFS = SearchAlgorithm(iota)
 	// Breadth-First Search
 	BFS
+	// Depth-First Search, vertex is returned before vertexes reachable from it
+	DFSPreOrder
+	// Depth-First Search, vertexes are returned in reverse post-order,
+	// so for acyclic graph each vertex is returned before all vertexes reachable from it
+	DFSReversePostOrder
+	// Depth-First Search, vertex is returned after all vertexes reachable from it
+	DFSPostOrder = DFS
 )
 
-func (sd SearchAlgorithm) StartAt(vtx *Vertex) GraphIterator {
+// Direction of edges followed during traversal
+type Direction int
+
+const (
+	// follow outcoming edges, it is the default
+	OutcomingDirection = Direction(iota)
+	// follow incoming edges
+	IncomingDirection
+	// follow both outcoming and incoming edges
+	BothDirections
+)
+
+// Option that limits what part of the graph is traversed
+type TraversalOption func(t *traversal)
+
+// Vertexes further than `depth` edges from the start vertex are not visited.
+// Depth is counted over edges vertexes were reached by, so with `DFS` a vertex may be
+// reached deeper than it is from the start vertex, use `BFS` to visit all vertexes within the distance.
+func MaxDepth(depth uint) TraversalOption {
+	return func(t *traversal) {
+		t.maxDepth = int(depth)
+	}
+}
+
+// Only edges that suit to the predicate are followed
+func OverEdges(predicate EdgePredicate) TraversalOption {
+	return func(t *traversal) {
+		t.predicate = predicate
+	}
+}
+
+// Only edges of provided direction are followed
+func InDirection(direction Direction) TraversalOption {
+	return func(t *traversal) {
+		t.direction = direction
+	}
+}
+
+type traversal struct {
+	// negative value means there is no limit
+	maxDepth  int
+	predicate EdgePredicate
+	direction Direction
+}
+
+func newTraversal(options []TraversalOption) traversal {
+	t := traversal{maxDepth: -1}
+	for _, option := range options {
+		option(&t)
+	}
+	return t
+}
+
+// returns edges to follow from the vertex reached at `depth`
+func (t traversal) ends(vtx *Vertex, depth int) []edgeEnd {
+	if t.maxDepth >= 0 && depth >= t.maxDepth {
+		return nil
+	}
+	var ends []edgeEnd
+	switch t.direction {
+	case OutcomingDirection:
+		ends = vtx.outcomingEnds()
+	case IncomingDirection:
+		ends = vtx.incomingEnds()
+	case BothDirections:
+		ends = vtx.incidentEnds()
+	}
+	if t.predicate == nil {
+		return ends
+	}
+	followed := ends[:0]
+	for _, end := range ends {
+		if t.predicate(end.edge) {
+			followed = append(followed, end)
+		}
+	}
+	return followed
+}
+
+// Returns iterator over all vertexes reachable from `vtx` by outcoming edges.
+// Each vertex is returned exactly once, so it is safe to use on graphs with cycles.
+// Options can limit depth of the search, edges to follow and their direction.
+func (sd SearchAlgorithm) StartAt(vtx *Vertex, options ...TraversalOption) GraphIterator {
+	return sd.startAt(vtx, newTraversal(options))
+}
+
+func (sd SearchAlgorithm) startAt(vtx *Vertex, t traversal) pathIterator {
 	switch sd {
-	case DFS:
-		dfs := &dfSearcherWrapper{}
-		dfs.searcher = &dfSearcher{vtx: vtx, vtxs: vtx.Outcoming().Vertexes(), wrapper: dfs}
-		return dfs
+	case DFSPostOrder:
+		return newDFSearcher(vtx, t, false)
+	case DFSPreOrder:
+		return newDFSearcher(vtx, t, true)
+	case DFSReversePostOrder:
+		return newReversePostOrderSearcher(vtx, t)
 	case BFS:
-		return &bfSearcher{check: []*Vertex{vtx}}
+		return &bfSearcher{traversal: t, check: []*Vertex{vtx}, depths: []int{0}, visited: NewVertexSet(vtx), reachedBy: reachedBy{}}
 	default:
 		return badSearcher{}
 	}
 }
+
+// Returns discovery and finish moments of all vertexes visited by depth-first search started at `vtx`
+func DFSVisitTimes(vtx *Vertex, options ...TraversalOption) map[*Vertex]VisitTime {
+	dfs := newDFSearcher(vtx, newTraversal(options), true)
+	for dfs.HasNext() {
+		dfs.Next()
+	}
+	return dfs.times
+}
+
+// returns the vertex and all vertexes reachable from it by outcoming edges
+func reachable(vtx *Vertex) VertexSet {
+	vs := NewVertexSet()
+	for iterator := BFS.StartAt(vtx); iterator.HasNext(); {
+		vs.put(iterator.Next())
+	}
+	return vs
+}
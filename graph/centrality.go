@@ -0,0 +1,264 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+)
+
+// iterative algorithm didn't reach requested tolerance
+var ErrNotConverged = errors.New("graph: algorithm did not converge")
+
+// maximal amount of iterations made by iterative algorithms
+const maxIterations = 1000
+
+// Score of each vertex
+type Scores map[*Vertex]float64
+
+// Vertex together with its score
+type RankedVertex struct {
+	Vertex *Vertex
+	Score  float64
+}
+
+// Returns vertexes of the set from the highest score to the lowest one, vertexes with equal scores keep order of the set
+func (s Scores) Ranked(vs VertexSet) []RankedVertex {
+	ranked := make([]RankedVertex, 0, vs.Len())
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		ranked = append(ranked, RankedVertex{Vertex: vtx, Score: s[vtx]})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// Amount of edges of each vertex of the set in provided direction, only edges between vertexes of the set are counted
+func DegreeCentrality(vs VertexSet, direction Direction) Scores {
+	t := newTraversal([]TraversalOption{InDirection(direction)})
+	scores := Scores{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		scores[vtx] = 0
+		for _, end := range t.ends(vtx, 0) {
+			if vs.Contains(end.vertex) {
+				scores[vtx]++
+			}
+		}
+	}
+	return scores
+}
+
+// For each vertex of the set sums parts of the shortest paths between other vertexes that go through it,
+// computed with Brandes' algorithm. `nil` weight means each edge has weight 1.
+// Paths over undirected edges are counted in both directions.
+func BetweennessCentrality(vs VertexSet, weight EdgeWeight) Scores {
+	neighbours := weightedNeighbours(vs, weight)
+	scores := Scores{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		source := iterator.Next()
+		scores[source] += 0
+		sp := shortestPaths(source, neighbours)
+		dependencies := map[*Vertex]float64{}
+		for i := len(sp.order) - 1; i >= 0; i-- {
+			vtx := sp.order[i]
+			for _, previous := range sp.previous[vtx] {
+				dependencies[previous] += sp.counts[previous] / sp.counts[vtx] * (1 + dependencies[vtx])
+			}
+			if vtx != source {
+				scores[vtx] += dependencies[vtx]
+			}
+		}
+	}
+	return scores
+}
+
+// For each vertex of the set returns inverse of the average distance to vertexes reachable from it,
+// scaled by the part of the set that is reachable, so vertexes of small components don't get high scores.
+// `nil` weight means each edge has weight 1.
+func ClosenessCentrality(vs VertexSet, weight EdgeWeight) Scores {
+	neighbours := weightedNeighbours(vs, weight)
+	scores := Scores{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		source := iterator.Next()
+		sp := shortestPaths(source, neighbours)
+		var total float64
+		for _, vtx := range sp.order {
+			total += sp.distances[vtx]
+		}
+		scores[source] = 0
+		if reached := float64(len(sp.distances) - 1); total > 0 && vs.Len() > 1 {
+			scores[source] = reached / total * reached / float64(vs.Len()-1)
+		}
+	}
+	return scores
+}
+
+// Scores each vertex of the set proportionally to the sum of scores of vertexes that have edges to it,
+// computed with power iteration until change of scores is less than tolerance. Scores have unit euclidean norm.
+func EigenvectorCentrality(vs VertexSet, tolerance float64) (Scores, error) {
+	scores := Scores{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		scores[iterator.Next()] = 1 / float64(vs.Len())
+	}
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		// previous scores are added, so iteration converges for bipartite graphs too
+		next := Scores{}
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			vtx := iterator.Next()
+			next[vtx] += scores[vtx]
+			for _, end := range vtx.outcomingEnds() {
+				if vs.Contains(end.vertex) {
+					next[end.vertex] += scores[vtx]
+				}
+			}
+		}
+		var norm float64
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			score := next[iterator.Next()]
+			norm += score * score
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return next, nil
+		}
+		var change float64
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			vtx := iterator.Next()
+			next[vtx] /= norm
+			change += math.Abs(next[vtx] - scores[vtx])
+		}
+		scores = next
+		if change < float64(vs.Len())*tolerance {
+			return scores, nil
+		}
+	}
+	return scores, ErrNotConverged
+}
+
+// Scores each vertex of the set by probability to be at it after random walk over edges, where at each step
+// walk follows a random edge with `damping` probability or jumps to a random vertex otherwise.
+// Only edges between vertexes of the set are followed, vertexes without such edges lead to all vertexes,
+// so scores sum up to 1. Computation stops when change of scores is less than tolerance.
+func PageRank(vs VertexSet, damping, tolerance float64) (Scores, error) {
+	scores := Scores{}
+	if vs.Len() == 0 {
+		return scores, nil
+	}
+	size := float64(vs.Len())
+	degrees := DegreeCentrality(vs, OutcomingDirection)
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		scores[iterator.Next()] = 1 / size
+	}
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		var dangling float64
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			if vtx := iterator.Next(); degrees[vtx] == 0 {
+				dangling += scores[vtx]
+			}
+		}
+		next := Scores{}
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			vtx := iterator.Next()
+			next[vtx] += (1-damping)/size + damping*dangling/size
+			if degrees[vtx] == 0 {
+				continue
+			}
+			for _, end := range vtx.outcomingEnds() {
+				if vs.Contains(end.vertex) {
+					next[end.vertex] += damping * scores[vtx] / degrees[vtx]
+				}
+			}
+		}
+		var change float64
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			vtx := iterator.Next()
+			change += math.Abs(next[vtx] - scores[vtx])
+		}
+		scores = next
+		if change < size*tolerance {
+			return scores, nil
+		}
+	}
+	return scores, ErrNotConverged
+}
+
+type weightedNeighbour struct {
+	vtx    *Vertex
+	weight float64
+}
+
+// returns distinct vertexes of the set reachable with one outcoming edge from each vertex of the set,
+// weight of parallel edges is the smallest one, `nil` weight means each edge has weight 1
+func weightedNeighbours(vs VertexSet, weight EdgeWeight) map[*Vertex][]weightedNeighbour {
+	if weight == nil {
+		weight = UnitWeight
+	}
+	neighbours := map[*Vertex][]weightedNeighbour{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		positions := map[*Vertex]int{}
+		for _, end := range vtx.outcomingEnds() {
+			if !vs.Contains(end.vertex) || end.vertex == vtx {
+				continue
+			}
+			edgeWeight := weight(end.edge)
+			if position, found := positions[end.vertex]; found {
+				if edgeWeight < neighbours[vtx][position].weight {
+					neighbours[vtx][position].weight = edgeWeight
+				}
+				continue
+			}
+			positions[end.vertex] = len(neighbours[vtx])
+			neighbours[vtx] = append(neighbours[vtx], weightedNeighbour{vtx: end.vertex, weight: edgeWeight})
+		}
+	}
+	return neighbours
+}
+
+// Shortest paths from a single vertex
+type singleSourcePaths struct {
+	// reached vertexes in order of not decreasing distance
+	order     []*Vertex
+	distances map[*Vertex]float64
+	// amount of different shortest paths to each vertex
+	counts map[*Vertex]float64
+	// vertexes preceding each vertex on its shortest paths
+	previous map[*Vertex][]*Vertex
+}
+
+func shortestPaths(source *Vertex, neighbours map[*Vertex][]weightedNeighbour) singleSourcePaths {
+	sp := singleSourcePaths{
+		distances: map[*Vertex]float64{source: 0},
+		counts:    map[*Vertex]float64{source: 1},
+		previous:  map[*Vertex][]*Vertex{},
+	}
+	settled := NewVertexSet()
+	queue := &costQueue{}
+	heap.Push(queue, costQueueItem{vtx: source})
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(costQueueItem)
+		if settled.Contains(item.vtx) {
+			continue
+		}
+		settled.put(item.vtx)
+		sp.order = append(sp.order, item.vtx)
+		for _, neighbour := range neighbours[item.vtx] {
+			distance := item.cost + neighbour.weight
+			known, found := sp.distances[neighbour.vtx]
+			switch {
+			case !found || distance < known:
+				sp.distances[neighbour.vtx] = distance
+				sp.counts[neighbour.vtx] = sp.counts[item.vtx]
+				sp.previous[neighbour.vtx] = []*Vertex{item.vtx}
+				heap.Push(queue, costQueueItem{vtx: neighbour.vtx, cost: distance, priority: distance})
+			case distance == known && !settled.Contains(neighbour.vtx):
+				sp.counts[neighbour.vtx] += sp.counts[item.vtx]
+				sp.previous[neighbour.vtx] = append(sp.previous[neighbour.vtx], item.vtx)
+			}
+		}
+	}
+	return sp
+}
@@ -0,0 +1,235 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Algorithm of coloring vertexes
+type ColoringAlgorithm int
+
+const (
+	// Vertexes are colored in order of the set with the smallest color none of their neighbours has
+	Greedy = ColoringAlgorithm(iota)
+	// Vertexes with the most distinct colors among neighbours are colored first
+	DSatur
+	// Vertexes with the most neighbours take the first color while it is possible, then the second one and so on
+	WelshPowell
+)
+
+// Colors vertexes of the set so vertexes connected with an edge have different colors, trying to use as few colors as possible.
+// Only edges between vertexes of the set are considered, their direction and self-loops are ignored.
+// Group key is a number of color starting from 0 and vertexes of each color keep order of the set.
+func Coloring(vs VertexSet, algorithm ColoringAlgorithm) []GroupedVertexes {
+	network := newWeightedNetwork(vs, nil)
+	var colors []int
+	switch algorithm {
+	case Greedy:
+		colors = network.greedyColoring()
+	case DSatur:
+		colors = network.dsaturColoring()
+	case WelshPowell:
+		colors = network.welshPowellColoring()
+	default:
+		return nil
+	}
+	var groups []GroupedVertexes
+	for vtx, color := range colors {
+		for color >= len(groups) {
+			groups = append(groups, GroupedVertexes{GroupKey: []byte(strconv.Itoa(len(groups)))})
+		}
+		groups[color].Vertexes = append(groups[color].Vertexes, network.vertexes[vtx])
+	}
+	return groups
+}
+
+// Returns vertexes of the set none of which are connected with an edge, such that no other vertex of the set can be added.
+// Vertexes with fewer neighbours are taken first. Only edges between vertexes of the set are considered, self-loops are ignored.
+func MaximalIndependentSet(vs VertexSet) VertexSet {
+	network := newWeightedNetwork(vs, nil)
+	order := make([]int, len(network.arcs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return network.neighbourCount(order[i]) < network.neighbourCount(order[j])
+	})
+	excluded := make([]bool, len(network.vertexes))
+	chosen := make([]bool, len(network.vertexes))
+	for _, vtx := range order {
+		if excluded[vtx] {
+			continue
+		}
+		chosen[vtx] = true
+		for _, arc := range network.arcs[vtx] {
+			excluded[arc.to] = true
+		}
+	}
+	independent := NewVertexSet()
+	for vtx, isChosen := range chosen {
+		if isChosen {
+			independent.put(network.vertexes[vtx])
+		}
+	}
+	return independent
+}
+
+// Returns all maximal cliques of vertexes of the set: groups of vertexes connected with each other that can't be extended,
+// found with Bron–Kerbosch algorithm with pivoting. Only edges between vertexes of the set are considered,
+// their direction and self-loops are ignored. Vertexes of each clique keep order of the set.
+func MaximalCliques(vs VertexSet) []VertexSet {
+	network := newWeightedNetwork(vs, nil)
+	neighbours := make([]map[int]bool, len(network.arcs))
+	candidates := make([]int, len(network.arcs))
+	for vtx, arcs := range network.arcs {
+		candidates[vtx] = vtx
+		neighbours[vtx] = map[int]bool{}
+		for _, arc := range arcs {
+			if arc.to != vtx {
+				neighbours[vtx][arc.to] = true
+			}
+		}
+	}
+	var cliques []VertexSet
+	var extend func(clique, candidates, excluded []int)
+	extend = func(clique, candidates, excluded []int) {
+		if len(candidates) == 0 && len(excluded) == 0 {
+			found := NewVertexSet()
+			for _, vtx := range sortedCopy(clique) {
+				found.put(network.vertexes[vtx])
+			}
+			cliques = append(cliques, found)
+			return
+		}
+		// pivot with the most neighbours among candidates leaves the fewest branches
+		pivot, pivotNeighbours := -1, -1
+		for _, vtxs := range [][]int{candidates, excluded} {
+			for _, vtx := range vtxs {
+				if count := len(intersection(candidates, neighbours[vtx])); count > pivotNeighbours {
+					pivot, pivotNeighbours = vtx, count
+				}
+			}
+		}
+		for _, vtx := range candidates {
+			if neighbours[pivot][vtx] {
+				continue
+			}
+			extend(append(clique[:len(clique):len(clique)], vtx), intersection(candidates, neighbours[vtx]), intersection(excluded, neighbours[vtx]))
+			candidates = without(candidates, vtx)
+			excluded = append(excluded[:len(excluded):len(excluded)], vtx)
+		}
+	}
+	extend(nil, candidates, nil)
+	return cliques
+}
+
+func (n *weightedNetwork) greedyColoring() []int {
+	order := make([]int, len(n.arcs))
+	for i := range order {
+		order[i] = i
+	}
+	return n.colorInOrder(order)
+}
+
+func (n *weightedNetwork) welshPowellColoring() []int {
+	return n.colorInOrder(n.orderByDegree())
+}
+
+// colors each vertex in provided order with the smallest color none of its neighbours has
+func (n *weightedNetwork) colorInOrder(order []int) []int {
+	colors := make([]int, len(n.arcs))
+	for i := range colors {
+		colors[i] = -1
+	}
+	for _, vtx := range order {
+		colors[vtx] = n.smallestFreeColor(vtx, colors)
+	}
+	return colors
+}
+
+func (n *weightedNetwork) dsaturColoring() []int {
+	colors := make([]int, len(n.arcs))
+	saturation := make([]map[int]bool, len(n.arcs))
+	for i := range colors {
+		colors[i] = -1
+		saturation[i] = map[int]bool{}
+	}
+	for range colors {
+		next := -1
+		for vtx, color := range colors {
+			if color >= 0 {
+				continue
+			}
+			if next < 0 || len(saturation[vtx]) > len(saturation[next]) ||
+				len(saturation[vtx]) == len(saturation[next]) && n.neighbourCount(vtx) > n.neighbourCount(next) {
+				next = vtx
+			}
+		}
+		colors[next] = n.smallestFreeColor(next, colors)
+		for _, arc := range n.arcs[next] {
+			saturation[arc.to][colors[next]] = true
+		}
+	}
+	return colors
+}
+
+func (n *weightedNetwork) smallestFreeColor(vtx int, colors []int) int {
+	used := map[int]bool{}
+	for _, arc := range n.arcs[vtx] {
+		if arc.to != vtx && colors[arc.to] >= 0 {
+			used[colors[arc.to]] = true
+		}
+	}
+	color := 0
+	for used[color] {
+		color++
+	}
+	return color
+}
+
+// returns vertexes from the one with the most neighbours, vertexes with equal amount of neighbours keep their order
+func (n *weightedNetwork) orderByDegree() []int {
+	order := make([]int, len(n.arcs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return n.neighbourCount(order[i]) > n.neighbourCount(order[j])
+	})
+	return order
+}
+
+// amount of distinct neighbours without vertex itself
+func (n *weightedNetwork) neighbourCount(vtx int) int {
+	count := len(n.arcs[vtx])
+	for _, arc := range n.arcs[vtx] {
+		if arc.to == vtx {
+			count--
+		}
+	}
+	return count
+}
+
+func intersection(vtxs []int, with map[int]bool) (res []int) {
+	for _, vtx := range vtxs {
+		if with[vtx] {
+			res = append(res, vtx)
+		}
+	}
+	return
+}
+
+func without(vtxs []int, vtx int) (res []int) {
+	for _, other := range vtxs {
+		if other != vtx {
+			res = append(res, other)
+		}
+	}
+	return
+}
+
+func sortedCopy(vtxs []int) []int {
+	res := append([]int(nil), vtxs...)
+	sort.Ints(res)
+	return res
+}
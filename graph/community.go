@@ -0,0 +1,292 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Algorithm of detecting communities
+type CommunityAlgorithm int
+
+const (
+	// Label propagation, each vertex repeatedly takes the label most of its neighbours have
+	LabelPropagation = CommunityAlgorithm(iota)
+	// Louvain method, vertexes are moved between communities while modularity grows,
+	// then communities are merged into single vertexes and the process repeats
+	Louvain
+)
+
+// Splits vertexes of the set into communities: groups of vertexes with more edges inside than between them.
+// Only edges between vertexes of the set are considered and their direction is ignored, `nil` weight means
+// each edge has weight 1. Group key is a number of community, communities are ordered by their first vertex
+// and vertexes of each community keep order of the set, so result doesn't change between calls.
+func Communities(vs VertexSet, algorithm CommunityAlgorithm, weight EdgeWeight) []GroupedVertexes {
+	network := newWeightedNetwork(vs, weight)
+	switch algorithm {
+	case LabelPropagation:
+		return network.groups(network.propagateLabels())
+	case Louvain:
+		return network.groups(network.louvain())
+	default:
+		return nil
+	}
+}
+
+// Returns modularity of splitting vertexes of the set into groups: the part of edge weights inside groups
+// minus the part expected if edges were placed randomly. Vertexes that are not in any group form groups on their own.
+func Modularity(vs VertexSet, groups []GroupedVertexes, weight EdgeWeight) float64 {
+	network := newWeightedNetwork(vs, weight)
+	communities := make([]int, len(network.vertexes))
+	for i := range communities {
+		communities[i] = len(groups) + i
+	}
+	for group, grouped := range groups {
+		for _, vtx := range grouped.Vertexes {
+			if index, found := network.indexes[vtx]; found {
+				communities[index] = group
+			}
+		}
+	}
+	return network.modularity(communities)
+}
+
+type weightedArc struct {
+	to     int
+	weight float64
+}
+
+// Undirected weighted graph over vertexes of the set, vertexes are numbered in order of the set
+type weightedNetwork struct {
+	vertexes []*Vertex
+	indexes  map[*Vertex]int
+	// neighbours of each vertex ordered by their numbers, self-loop has double weight
+	arcs [][]weightedArc
+	// sum of weights of arcs of each vertex
+	degrees []float64
+	// double sum of weights of all edges
+	total float64
+}
+
+func newWeightedNetwork(vs VertexSet, weight EdgeWeight) *weightedNetwork {
+	if weight == nil {
+		weight = UnitWeight
+	}
+	network := &weightedNetwork{indexes: map[*Vertex]int{}}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		network.indexes[vtx] = len(network.vertexes)
+		network.vertexes = append(network.vertexes, vtx)
+	}
+	weights := make([]map[int]float64, len(network.vertexes))
+	for i := range weights {
+		weights[i] = map[int]float64{}
+	}
+	for from, vtx := range network.vertexes {
+		for _, end := range vtx.ownOutcomingEnds() {
+			to, found := network.indexes[end.vertex]
+			if !found {
+				continue
+			}
+			edgeWeight := weight(end.edge)
+			weights[from][to] += edgeWeight
+			weights[to][from] += edgeWeight
+		}
+	}
+	network.setWeights(weights)
+	return network
+}
+
+func (n *weightedNetwork) setWeights(weights []map[int]float64) {
+	n.arcs = make([][]weightedArc, len(weights))
+	n.degrees = make([]float64, len(weights))
+	n.total = 0
+	for from, neighbours := range weights {
+		for to, weight := range neighbours {
+			n.arcs[from] = append(n.arcs[from], weightedArc{to: to, weight: weight})
+		}
+		sort.Slice(n.arcs[from], func(i, j int) bool { return n.arcs[from][i].to < n.arcs[from][j].to })
+		for _, arc := range n.arcs[from] {
+			n.degrees[from] += arc.weight
+		}
+		n.total += n.degrees[from]
+	}
+}
+
+// returns label of each vertex, initially each vertex has its own label and at each round vertexes in order
+// take the label with the biggest weight of edges to neighbours, keeping their own one on ties or the biggest one
+func (n *weightedNetwork) propagateLabels() []int {
+	labels := make([]int, len(n.arcs))
+	for i := range labels {
+		labels[i] = i
+	}
+	for iteration, changed := 0, true; changed && iteration < maxIterations; iteration++ {
+		changed = false
+		for vtx, arcs := range n.arcs {
+			weights := map[int]float64{}
+			for _, arc := range arcs {
+				if arc.to != vtx {
+					weights[labels[arc.to]] += arc.weight
+				}
+			}
+			var heaviest float64
+			for _, weight := range weights {
+				if weight > heaviest {
+					heaviest = weight
+				}
+			}
+			best := labels[vtx]
+			if weights[best] < heaviest {
+				best = -1
+				for label, weight := range weights {
+					if weight == heaviest && label > best {
+						best = label
+					}
+				}
+			}
+			if best != labels[vtx] {
+				labels[vtx] = best
+				changed = true
+			}
+		}
+	}
+	return labels
+}
+
+// returns community of each vertex found by Louvain method
+func (n *weightedNetwork) louvain() []int {
+	communities := make([]int, len(n.arcs))
+	for i := range communities {
+		communities[i] = i
+	}
+	level := &weightedNetwork{arcs: n.arcs, degrees: n.degrees, total: n.total}
+	for {
+		moved, levelCommunities := level.moveVertexes()
+		if !moved {
+			return communities
+		}
+		renumbered := renumber(levelCommunities)
+		for i, community := range communities {
+			communities[i] = renumbered[community]
+		}
+		level = level.aggregate(renumbered)
+	}
+}
+
+// moves each vertex to the neighbour community with the biggest gain of modularity while it grows,
+// returns whether any vertex was moved together with community of each vertex
+func (n *weightedNetwork) moveVertexes() (bool, []int) {
+	communities := make([]int, len(n.arcs))
+	totals := make([]float64, len(n.arcs))
+	for i := range communities {
+		communities[i] = i
+		totals[i] = n.degrees[i]
+	}
+	if n.total == 0 {
+		return false, communities
+	}
+	moved := false
+	for iteration, changed := 0, true; changed && iteration < maxIterations; iteration++ {
+		changed = false
+		for vtx, arcs := range n.arcs {
+			current := communities[vtx]
+			totals[current] -= n.degrees[vtx]
+			weights := map[int]float64{}
+			var candidates []int
+			for _, arc := range arcs {
+				if arc.to == vtx {
+					continue
+				}
+				community := communities[arc.to]
+				if _, found := weights[community]; !found {
+					candidates = append(candidates, community)
+				}
+				weights[community] += arc.weight
+			}
+			gain := func(community int) float64 {
+				return weights[community] - totals[community]*n.degrees[vtx]/n.total
+			}
+			best := current
+			for _, community := range candidates {
+				if gain(community) > gain(best)+modularityTolerance {
+					best = community
+				}
+			}
+			totals[best] += n.degrees[vtx]
+			if best != current {
+				communities[vtx] = best
+				changed, moved = true, true
+			}
+		}
+	}
+	return moved, communities
+}
+
+// smallest growth of modularity that is not considered as a rounding error
+const modularityTolerance = 1e-12
+
+// returns network where each community is a single vertex and edges inside it form a self-loop
+func (n *weightedNetwork) aggregate(communities []int) *weightedNetwork {
+	size := 0
+	for _, community := range communities {
+		if community >= size {
+			size = community + 1
+		}
+	}
+	weights := make([]map[int]float64, size)
+	for i := range weights {
+		weights[i] = map[int]float64{}
+	}
+	for from, arcs := range n.arcs {
+		for _, arc := range arcs {
+			weights[communities[from]][communities[arc.to]] += arc.weight
+		}
+	}
+	aggregated := &weightedNetwork{}
+	aggregated.setWeights(weights)
+	return aggregated
+}
+
+func (n *weightedNetwork) modularity(communities []int) float64 {
+	if n.total == 0 {
+		return 0
+	}
+	var inside float64
+	totals := map[int]float64{}
+	for from, arcs := range n.arcs {
+		totals[communities[from]] += n.degrees[from]
+		for _, arc := range arcs {
+			if communities[from] == communities[arc.to] {
+				inside += arc.weight
+			}
+		}
+	}
+	modularity := inside / n.total
+	for _, total := range totals {
+		modularity -= (total / n.total) * (total / n.total)
+	}
+	return modularity
+}
+
+// numbers communities from 0 in order of their first vertex
+func renumber(communities []int) []int {
+	numbers := map[int]int{}
+	renumbered := make([]int, len(communities))
+	for i, community := range communities {
+		if _, found := numbers[community]; !found {
+			numbers[community] = len(numbers)
+		}
+		renumbered[i] = numbers[community]
+	}
+	return renumbered
+}
+
+func (n *weightedNetwork) groups(communities []int) []GroupedVertexes {
+	var groups []GroupedVertexes
+	for vtx, community := range renumber(communities) {
+		if community == len(groups) {
+			groups = append(groups, GroupedVertexes{GroupKey: []byte(strconv.Itoa(community))})
+		}
+		groups[community].Vertexes = append(groups[community].Vertexes, n.vertexes[vtx])
+	}
+	return groups
+}
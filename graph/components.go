@@ -0,0 +1,222 @@
+package graph
+
+import "sort"
+
+// Algorithm of searching strongly connected components
+type ComponentsAlgorithm int
+
+const (
+	// Tarjan's algorithm, single depth-first search with low-link values
+	Tarjan = ComponentsAlgorithm(iota)
+	// Kosaraju's algorithm, depth-first search over edges and then over reversed edges
+	Kosaraju
+)
+
+// Splits vertexes of the set into groups where each vertex is reachable from any other vertex of the group.
+// Only edges between vertexes of the set are considered. Components are ordered by the first of their vertexes
+// and vertexes of a component keep order of the set, so result doesn't depend on algorithm.
+func StronglyConnectedComponents(vs VertexSet, algorithm ComponentsAlgorithm) []VertexSet {
+	switch algorithm {
+	case Tarjan:
+		return orderComponents(vs, tarjanComponents(vs))
+	case Kosaraju:
+		return orderComponents(vs, kosarajuComponents(vs))
+	default:
+		return nil
+	}
+}
+
+// Splits vertexes of the set into groups connected with edges of any direction.
+// Only edges between vertexes of the set are considered, components are ordered as in `StronglyConnectedComponents`.
+func WeaklyConnectedComponents(vs VertexSet) []VertexSet {
+	var components [][]*Vertex
+	assigned := NewVertexSet()
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		root := iterator.Next()
+		if assigned.Contains(root) {
+			continue
+		}
+		assigned.put(root)
+		component := []*Vertex{root}
+		for index := 0; index < len(component); index++ {
+			for _, end := range component[index].incidentEnds() {
+				if vs.Contains(end.vertex) && !assigned.Contains(end.vertex) {
+					assigned.put(end.vertex)
+					component = append(component, end.vertex)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return orderComponents(vs, components)
+}
+
+// Builds acyclic graph where each vertex holds `VertexSet` of a strongly connected component and
+// there is an edge between two components if there is at least one edge between their vertexes.
+// Attributes of such edge are `EdgeSet` of all edges between vertexes of the components.
+func Condensation(vs VertexSet) *Graph {
+	g := NewGraph()
+	componentOf := map[*Vertex]*Vertex{}
+	for _, component := range StronglyConnectedComponents(vs, Tarjan) {
+		cVtx := g.AddVertex(component)
+		for iterator := component.Iterator(); iterator.HasNext(); {
+			componentOf[iterator.Next()] = cVtx
+		}
+	}
+
+	linkGroups(vs, componentOf)
+	return g
+}
+
+// Links vertexes of groups with an edge for each pair of groups that have outcoming edges from vertexes of one group
+// to vertexes of another one, attributes of such edge is `EdgeSet` with all these edges in order of vertexes of the set.
+// Edges inside groups and edges to vertexes without a group are skipped.
+func linkGroups(vs VertexSet, groupOf map[*Vertex]*Vertex) {
+	between := map[[2]*Vertex]EdgeSet{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		for _, end := range vtx.outcomingEnds() {
+			to, found := groupOf[end.vertex]
+			if !found || groupOf[vtx] == to {
+				continue
+			}
+			key := [2]*Vertex{groupOf[vtx], to}
+			es, found := between[key]
+			if !found {
+				es = NewEdgeSet()
+				between[key] = es
+				key[0].EdgeToWith(key[1], es)
+			}
+			es.put(end)
+		}
+	}
+}
+
+func tarjanComponents(vs VertexSet) (components [][]*Vertex) {
+	index := map[*Vertex]int{}
+	lowLink := map[*Vertex]int{}
+	onStack := map[*Vertex]bool{}
+	var stack []*Vertex
+	var frames []dfFrame
+	visit := func(vtx *Vertex) {
+		index[vtx] = len(index)
+		lowLink[vtx] = index[vtx]
+		onStack[vtx] = true
+		stack = append(stack, vtx)
+		frames = append(frames, dfFrame{vtx: vtx, ends: vtx.outcomingEnds()})
+	}
+
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		root := iterator.Next()
+		if _, visited := index[root]; visited {
+			continue
+		}
+		visit(root)
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			if top.index < len(top.ends) {
+				end := top.ends[top.index]
+				top.index++
+				if !vs.Contains(end.vertex) {
+					continue
+				}
+				if _, visited := index[end.vertex]; !visited {
+					visit(end.vertex)
+				} else if onStack[end.vertex] && index[end.vertex] < lowLink[top.vtx] {
+					lowLink[top.vtx] = index[end.vertex]
+				}
+				continue
+			}
+
+			vtx := top.vtx
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				if parent := frames[len(frames)-1].vtx; lowLink[vtx] < lowLink[parent] {
+					lowLink[parent] = lowLink[vtx]
+				}
+			}
+			if lowLink[vtx] != index[vtx] {
+				continue
+			}
+			// the vertex is the root of the component, all vertexes above it on the stack belong to it
+			var component []*Vertex
+			for {
+				last := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[last] = false
+				component = append(component, last)
+				if last == vtx {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+	return
+}
+
+func kosarajuComponents(vs VertexSet) (components [][]*Vertex) {
+	visited := NewVertexSet()
+	var finished []*Vertex
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		root := iterator.Next()
+		if visited.Contains(root) {
+			continue
+		}
+		visited.put(root)
+		frames := []dfFrame{{vtx: root, ends: root.outcomingEnds()}}
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			if top.index == len(top.ends) {
+				finished = append(finished, top.vtx)
+				frames = frames[:len(frames)-1]
+				continue
+			}
+			end := top.ends[top.index]
+			top.index++
+			if vs.Contains(end.vertex) && !visited.Contains(end.vertex) {
+				visited.put(end.vertex)
+				frames = append(frames, dfFrame{vtx: end.vertex, ends: end.vertex.outcomingEnds()})
+			}
+		}
+	}
+
+	// vertexes that finished later are roots of components, everything reachable
+	// from them over reversed edges and not assigned yet belongs to their components
+	assigned := NewVertexSet()
+	for i := len(finished) - 1; i >= 0; i-- {
+		root := finished[i]
+		if assigned.Contains(root) {
+			continue
+		}
+		assigned.put(root)
+		component := []*Vertex{root}
+		for index := 0; index < len(component); index++ {
+			for _, end := range component[index].incomingEnds() {
+				if vs.Contains(end.vertex) && !assigned.Contains(end.vertex) {
+					assigned.put(end.vertex)
+					component = append(component, end.vertex)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return
+}
+
+// converts components into sets ordered by positions of vertexes in `vs`
+func orderComponents(vs VertexSet, components [][]*Vertex) []VertexSet {
+	for _, component := range components {
+		sort.Slice(component, func(i, j int) bool {
+			return vs.set[component[i]] < vs.set[component[j]]
+		})
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return vs.set[components[i][0]] < vs.set[components[j][0]]
+	})
+	sets := make([]VertexSet, 0, len(components))
+	for _, component := range components {
+		sets = append(sets, NewVertexSet(component...))
+	}
+	return sets
+}
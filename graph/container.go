@@ -0,0 +1,186 @@
+package graph
+
+import "sync"
+
+// Container that owns vertexes and keeps track of all edges between them.
+// Vertexes that are linked with a vertex of the graph become part of the graph as well.
+type Graph struct {
+	vertexes VertexSet
+	edges    EdgeSet
+	// lock shared by all vertexes of a concurrent graph, `nil` for other graphs
+	guard *sync.RWMutex
+	// not `nil` for a view of a snapshot
+	snapshot *Snapshot
+	// epoch of the graph is increased by each snapshot, snapshots that are not released keep their epochs
+	epoch     int
+	snapshots []int
+	// epoch vertexes and edges were changed at and their previous versions kept for snapshots
+	modified int
+	versions []graphVersion
+}
+
+func NewGraph() *Graph {
+	return &Graph{vertexes: NewVertexSet(), edges: NewEdgeSet()}
+}
+
+// Creates a graph that can be read and changed from several goroutines at once.
+// All vertexes and edges of the graph share a single read-write lock: changes of edges and attributes
+// are exclusive, reads return copies of vertex and edge sets, so they can be iterated while the graph changes.
+// Algorithms read edges of each vertex atomically, but see changes made by other goroutines while they run.
+// Free vertexes are not guarded until they are added to the graph, and a vertex detached from the graph
+// can be added back only to the same graph.
+func NewConcurrentGraph() *Graph {
+	g := NewGraph()
+	g.guard = &sync.RWMutex{}
+	return g
+}
+
+// Creates new vertex with provided data and registers it in the graph
+func (g *Graph) AddVertex(data interface{}) *Vertex {
+	return g.Add(VertexWith(data))
+}
+
+// Registers vertex in the graph with all vertexes and edges connected to it.
+// Panics if vertex already belongs to another graph.
+func (g *Graph) Add(vtx *Vertex) *Vertex {
+	if g.snapshot != nil {
+		panic("graph: snapshot is read-only")
+	}
+	lock(g.guard)
+	defer unlock(g.guard)
+	switch vtx.graph {
+	case g:
+	case nil:
+		g.adopt(vtx)
+	default:
+		panic("graph: vertex belongs to another graph")
+	}
+	return vtx
+}
+
+// All vertexes of the graph in order they were added, for a concurrent graph it is a copy
+func (g *Graph) Vertexes() VertexSet {
+	if g.snapshot != nil {
+		g.snapshot.materialize()
+	}
+	if g.guard == nil {
+		return g.vertexes
+	}
+	g.guard.RLock()
+	defer g.guard.RUnlock()
+	return g.vertexes.copy()
+}
+
+// All edges of the graph in order they were added, for a concurrent graph it is a copy
+func (g *Graph) Edges() EdgeSet {
+	if g.snapshot != nil {
+		g.snapshot.materialize()
+	}
+	if g.guard == nil {
+		return g.edges
+	}
+	g.guard.RLock()
+	defer g.guard.RUnlock()
+	return NewEdgeSet().Merge(g.edges)
+}
+
+// Amount of vertexes in the graph
+func (g *Graph) Order() int {
+	if g.snapshot != nil {
+		g.snapshot.materialize()
+	}
+	rlock(g.guard)
+	defer runlock(g.guard)
+	return g.vertexes.Len()
+}
+
+// Amount of edges in the graph
+func (g *Graph) Size() int {
+	if g.snapshot != nil {
+		g.snapshot.materialize()
+	}
+	rlock(g.guard)
+	defer runlock(g.guard)
+	return g.edges.Len()
+}
+
+// registers free vertex and everything connected to it
+func (g *Graph) adopt(vtx *Vertex) {
+	g.preserve()
+	g.register(vtx)
+	for check := []*Vertex{vtx}; len(check) > 0; check = check[1:] {
+		cVtx := check[0]
+		// the graph is already locked, so edges are taken without locking
+		for _, end := range ownEnds(cVtx, cVtx.outcoming.ends()) {
+			g.edges.put(end)
+			end.edge.modified = g.epoch
+		}
+		for iterator := cVtx.adjacent.Iterator(); iterator.HasNext(); {
+			aVtx := iterator.Next()
+			if aVtx.graph == nil {
+				g.register(aVtx)
+				check = append(check, aVtx)
+			}
+		}
+	}
+}
+
+func (g *Graph) register(vtx *Vertex) {
+	if vtx.guard != g.guard && vtx.guard != nil {
+		panic("graph: vertex was detached from another concurrent graph")
+	}
+	vtx.graph = g
+	vtx.guard = g.guard
+	vtx.modified = g.epoch
+	g.vertexes.put(vtx)
+}
+
+// makes both vertexes belong to the same graph if one of them is in a graph
+func joinGraphs(oneVtx, anotherVtx *Vertex) *Graph {
+	switch {
+	case oneVtx.graph == anotherVtx.graph:
+	case oneVtx.graph == nil:
+		anotherVtx.graph.adopt(oneVtx)
+	case anotherVtx.graph == nil:
+		oneVtx.graph.adopt(anotherVtx)
+	default:
+		panic("graph: vertexes belong to different graphs")
+	}
+	return oneVtx.graph
+}
+
+// returns lock that guards both vertexes, vertexes of different concurrent graphs can't be linked
+func guardOf(oneVtx, anotherVtx *Vertex) *sync.RWMutex {
+	if oneVtx.guard != nil && anotherVtx.guard != nil && oneVtx.guard != anotherVtx.guard {
+		panic("graph: vertexes belong to different graphs")
+	}
+	if oneVtx.guard != nil {
+		return oneVtx.guard
+	}
+	return anotherVtx.guard
+}
+
+// `nil` lock means the vertex or graph is not guarded
+func lock(guard *sync.RWMutex) {
+	if guard != nil {
+		guard.Lock()
+	}
+}
+
+func unlock(guard *sync.RWMutex) {
+	if guard != nil {
+		guard.Unlock()
+	}
+}
+
+func rlock(guard *sync.RWMutex) {
+	if guard != nil {
+		guard.RLock()
+	}
+}
+
+func runlock(guard *sync.RWMutex) {
+	if guard != nil {
+		guard.RUnlock()
+	}
+}
@@ -0,0 +1,164 @@
+package graph
+
+// Returns elementary cycles between vertexes of the set found by Johnson's algorithm.
+// Only edges that suit to the predicate are followed, `nil` predicate means all edges.
+// Undirected edges can be passed in both directions, but a cycle can't go back over the same edge,
+// and a cycle that goes only over undirected edges is returned once, in one of its directions.
+// Search stops when `limit` cycles are found, zero limit means all cycles are returned.
+// Each cycle is a path that starts and ends at the same vertex.
+func FindCycles(vs VertexSet, predicate EdgePredicate, limit uint) []Path {
+	cs := &cycleSearch{predicate: predicate, limit: limit}
+	for iterator := vs.Iterator(); iterator.HasNext() && !cs.done(); {
+		start := iterator.Next()
+		// cycles through vertexes of previous iterations were already found
+		cs.searchFrom(start, func(vtx *Vertex) bool {
+			position, found := vs.set[vtx]
+			return found && position >= vs.set[start]
+		})
+	}
+	return cs.cycles
+}
+
+// Returns elementary cycles that go through the vertex, see `FindCycles`
+func (vtx *Vertex) FindCycles(predicate EdgePredicate, limit uint) []Path {
+	cs := &cycleSearch{predicate: predicate, limit: limit}
+	cs.searchFrom(vtx, func(*Vertex) bool { return true })
+	return cs.cycles
+}
+
+// Reports if there is a cycle that goes through the vertex over edges that suit to the predicate
+func (vtx *Vertex) HasCycle(predicate EdgePredicate) bool {
+	return len(vtx.FindCycles(predicate, 1)) > 0
+}
+
+type cycleSearch struct {
+	predicate EdgePredicate
+	limit     uint
+	cycles    []Path
+
+	start *Vertex
+	// strongly connected component of the start vertex, cycles can't leave it
+	component VertexSet
+	blocked   map[*Vertex]bool
+	blockedBy map[*Vertex][]*Vertex
+	stack     []*Vertex
+}
+
+func (cs *cycleSearch) done() bool {
+	return cs.limit != 0 && uint(len(cs.cycles)) >= cs.limit
+}
+
+func (cs *cycleSearch) follows(edge *Edge) bool {
+	return cs.predicate == nil || cs.predicate(edge)
+}
+
+func (cs *cycleSearch) searchFrom(start *Vertex, allowed VertexPredicate) {
+	over := OverEdges(func(edge *Edge) bool {
+		return cs.follows(edge) && allowed(edge.from) && allowed(edge.to)
+	})
+	backward := NewVertexSet()
+	for iterator := BFS.StartAt(start, over, InDirection(IncomingDirection)); iterator.HasNext(); {
+		backward.put(iterator.Next())
+	}
+	cs.component = NewVertexSet()
+	for iterator := BFS.StartAt(start, over); iterator.HasNext(); {
+		if vtx := iterator.Next(); backward.Contains(vtx) {
+			cs.component.put(vtx)
+		}
+	}
+
+	cs.start = start
+	cs.blocked = map[*Vertex]bool{}
+	cs.blockedBy = map[*Vertex][]*Vertex{}
+	cs.circuit(start)
+}
+
+func (cs *cycleSearch) circuit(vtx *Vertex) (closed bool) {
+	cs.stack = append(cs.stack, vtx)
+	cs.blocked[vtx] = true
+	successors := cs.successors(vtx)
+	for _, successor := range successors {
+		if cs.done() {
+			break
+		}
+		if successor == cs.start {
+			cs.collect()
+			closed = true
+		} else if !cs.blocked[successor] && cs.circuit(successor) {
+			closed = true
+		}
+	}
+	if closed {
+		cs.unblock(vtx)
+	} else {
+		for _, successor := range successors {
+			cs.blockedBy[successor] = append(cs.blockedBy[successor], vtx)
+		}
+	}
+	cs.stack = cs.stack[:len(cs.stack)-1]
+	return
+}
+
+func (cs *cycleSearch) unblock(vtx *Vertex) {
+	cs.blocked[vtx] = false
+	blockedBy := cs.blockedBy[vtx]
+	delete(cs.blockedBy, vtx)
+	for _, bVtx := range blockedBy {
+		if cs.blocked[bVtx] {
+			cs.unblock(bVtx)
+		}
+	}
+}
+
+// returns distinct vertexes of the component that can be reached from the vertex with one edge
+func (cs *cycleSearch) successors(vtx *Vertex) []*Vertex {
+	vs := NewVertexSet()
+	for _, end := range vtx.outcomingEnds() {
+		if cs.component.Contains(end.vertex) && cs.follows(end.edge) {
+			vs.put(end.vertex)
+		}
+	}
+	successors := make([]*Vertex, 0, vs.Len())
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		successors = append(successors, iterator.Next())
+	}
+	return successors
+}
+
+// adds cycle formed by the stack if there are distinct edges between its vertexes
+func (cs *cycleSearch) collect() {
+	cycle := Path{vertexes: append(append([]*Vertex{}, cs.stack...), cs.start)}
+	used := map[*Edge]bool{}
+	for i, vtx := range cs.stack {
+		var next *Edge
+		for _, end := range vtx.outcomingEnds() {
+			if end.vertex == cycle.vertexes[i+1] && cs.follows(end.edge) && !used[end.edge] {
+				next = end.edge
+				break
+			}
+		}
+		if next == nil {
+			// the only edge between two vertexes is an undirected one
+			return
+		}
+		used[next] = true
+		cycle.edges = append(cycle.edges, next)
+	}
+	if cs.reversed(cycle) {
+		return
+	}
+	cycle.cost = float64(cycle.Len())
+	cs.cycles = append(cs.cycles, cycle)
+}
+
+// reports if the cycle goes only over undirected edges and it is the copy found in opposite direction,
+// the kept direction goes to the vertex that is earlier in the component first
+func (cs *cycleSearch) reversed(cycle Path) bool {
+	for _, edge := range cycle.edges {
+		if !edge.undirected {
+			return false
+		}
+	}
+	second, last := cycle.vertexes[1], cycle.vertexes[len(cycle.vertexes)-2]
+	return cs.component.set[second] > cs.component.set[last]
+}
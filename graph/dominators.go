@@ -0,0 +1,137 @@
+package graph
+
+// Returns common ancestors of both vertexes that don't have other common ancestors among their descendants.
+// Vertexes are treated as ancestors of themselves, so if one vertex is an ancestor of another one it is returned.
+// Only edges that suit to the predicate are followed, `nil` predicate means all edges.
+// Graph must be acyclic, in a directed acyclic graph there can be several lowest common ancestors.
+func LowestCommonAncestors(one, another *Vertex, predicate EdgePredicate) []*Vertex {
+	oneAncestors := one.Ancestors(predicate)
+	oneAncestors.put(one)
+	anotherAncestors := another.Ancestors(predicate)
+	anotherAncestors.put(another)
+
+	common := NewVertexSet()
+	for iterator := oneAncestors.Iterator(); iterator.HasNext(); {
+		if vtx := iterator.Next(); anotherAncestors.Contains(vtx) {
+			common.put(vtx)
+		}
+	}
+
+	var lowest []*Vertex
+	for iterator := common.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		// if any common ancestor is a descendant of the vertex, then all vertexes on the path to it are common ancestors too
+		isLowest := true
+		for _, end := range vtx.outcomingEnds() {
+			if end.vertex != vtx && common.Contains(end.vertex) && (predicate == nil || predicate(end.edge)) {
+				isLowest = false
+				break
+			}
+		}
+		if isLowest {
+			lowest = append(lowest, vtx)
+		}
+	}
+	return lowest
+}
+
+// Returns the closest of lowest common ancestors of both vertexes or `nil` if they don't have common ancestors,
+// see `LowestCommonAncestors`. For trees the lowest common ancestor is always single.
+func LowestCommonAncestor(one, another *Vertex, predicate EdgePredicate) *Vertex {
+	if lowest := LowestCommonAncestors(one, another, predicate); len(lowest) > 0 {
+		return lowest[0]
+	}
+	return nil
+}
+
+// Tree where parent of each vertex is its immediate dominator: the closest vertex
+// that is on every path from the root to the vertex
+type DominatorTree struct {
+	root      *Vertex
+	immediate map[*Vertex]*Vertex
+	dominated map[*Vertex][]*Vertex
+}
+
+// Builds dominator tree for all vertexes reachable from the root over outcoming edges
+// with iterative algorithm of Cooper, Harvey and Kennedy.
+func DominatorTreeOf(root *Vertex) *DominatorTree {
+	var order []*Vertex
+	for iterator := DFSReversePostOrder.StartAt(root); iterator.HasNext(); {
+		order = append(order, iterator.Next())
+	}
+	// vertex closer to the root has bigger post-order number
+	postOrder := map[*Vertex]int{}
+	for i, vtx := range order {
+		postOrder[vtx] = len(order) - i
+	}
+
+	immediate := map[*Vertex]*Vertex{root: root}
+	intersect := func(one, another *Vertex) *Vertex {
+		for one != another {
+			for postOrder[one] < postOrder[another] {
+				one = immediate[one]
+			}
+			for postOrder[another] < postOrder[one] {
+				another = immediate[another]
+			}
+		}
+		return one
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, vtx := range order[1:] {
+			var dominator *Vertex
+			for _, end := range vtx.incomingEnds() {
+				if _, processed := immediate[end.vertex]; !processed {
+					continue
+				}
+				if dominator == nil {
+					dominator = end.vertex
+				} else {
+					dominator = intersect(end.vertex, dominator)
+				}
+			}
+			if immediate[vtx] != dominator {
+				immediate[vtx] = dominator
+				changed = true
+			}
+		}
+	}
+
+	dt := &DominatorTree{root: root, immediate: immediate, dominated: map[*Vertex][]*Vertex{}}
+	for _, vtx := range order[1:] {
+		dt.dominated[immediate[vtx]] = append(dt.dominated[immediate[vtx]], vtx)
+	}
+	return dt
+}
+
+// Vertex the tree was built from
+func (dt *DominatorTree) Root() *Vertex {
+	return dt.root
+}
+
+// Returns immediate dominator of the vertex or `nil` for the root and vertexes unreachable from it
+func (dt *DominatorTree) ImmediateDominator(vtx *Vertex) *Vertex {
+	if vtx == dt.root {
+		return nil
+	}
+	return dt.immediate[vtx]
+}
+
+// Returns vertexes immediately dominated by the vertex, its children in the tree
+func (dt *DominatorTree) Dominated(vtx *Vertex) []*Vertex {
+	return dt.dominated[vtx]
+}
+
+// Reports if every path from the root to `vtx` goes through `dominator`, each vertex dominates itself
+func (dt *DominatorTree) Dominates(dominator, vtx *Vertex) bool {
+	if _, reachable := dt.immediate[vtx]; !reachable {
+		return false
+	}
+	for ; vtx != dt.root; vtx = dt.immediate[vtx] {
+		if vtx == dominator {
+			return true
+		}
+	}
+	return dominator == dt.root
+}
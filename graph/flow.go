@@ -0,0 +1,218 @@
+package graph
+
+import "math"
+
+// Algorithm of searching maximum flow
+type FlowAlgorithm int
+
+const (
+	// Edmonds-Karp algorithm, flow is pushed over the shortest augmenting path one by one
+	EdmondsKarp = FlowAlgorithm(iota)
+	// Dinic's algorithm, flow is pushed over all shortest augmenting paths at once
+	Dinic
+)
+
+// Maximum flow between two vertexes together with minimum cut that limits it
+type Flow struct {
+	value      float64
+	flows      map[*Edge]float64
+	sourceSide VertexSet
+	sinkSide   VertexSet
+}
+
+// Total amount of flow from the source to the sink
+func (f Flow) Value() float64 {
+	return f.value
+}
+
+// Amount of flow over the edge from its `From` vertex to its `To` vertex,
+// it is negative if flow goes over undirected edge in the opposite direction
+func (f Flow) Of(edge *Edge) float64 {
+	return f.flows[edge]
+}
+
+// Vertexes on the source side of the minimum cut, all of them are reachable from the source over edges with spare capacity
+func (f Flow) SourceSide() VertexSet {
+	return f.sourceSide
+}
+
+// Vertexes on the sink side of the minimum cut
+func (f Flow) SinkSide() VertexSet {
+	return f.sinkSide
+}
+
+// Searches maximum flow from the source to the sink over edges reachable from the source.
+// Capacity of each edge is provided by the callback, undirected edge can pass its capacity in any direction.
+func MaxFlow(source, sink *Vertex, algorithm FlowAlgorithm, capacity EdgeWeight) Flow {
+	if source == sink {
+		// there is nothing to push when the source is the sink
+		return Flow{flows: map[*Edge]float64{}, sourceSide: NewVertexSet(), sinkSide: NewVertexSet()}
+	}
+	network := newFlowNetwork(source, capacity)
+	flow := Flow{flows: map[*Edge]float64{}, sourceSide: NewVertexSet(), sinkSide: NewVertexSet()}
+	if sinkIndex, found := network.indexes[sink]; found {
+		switch algorithm {
+		case EdmondsKarp:
+			flow.value = network.edmondsKarp(sinkIndex)
+		case Dinic:
+			flow.value = network.dinic(sinkIndex)
+		}
+	}
+
+	for _, arc := range network.arcs {
+		if arc.forward {
+			flow.flows[arc.edge] = arc.capacity - arc.residual
+		}
+	}
+	levels := network.levels()
+	for index, vtx := range network.vtxs {
+		if levels[index] >= 0 {
+			flow.sourceSide.put(vtx)
+		} else {
+			flow.sinkSide.put(vtx)
+		}
+	}
+	return flow
+}
+
+type flowArc struct {
+	to       int
+	capacity float64
+	residual float64
+	// index of the arc in opposite direction
+	reverse int
+	edge    *Edge
+	// arc goes from `From` vertex to `To` vertex of the edge
+	forward bool
+}
+
+// Residual network, the source vertex has index 0
+type flowNetwork struct {
+	vtxs    []*Vertex
+	indexes map[*Vertex]int
+	arcs    []flowArc
+	// indexes of arcs that start at the vertex
+	outcoming [][]int
+}
+
+func newFlowNetwork(source *Vertex, capacity EdgeWeight) *flowNetwork {
+	network := &flowNetwork{indexes: map[*Vertex]int{}}
+	for iterator := BFS.StartAt(source); iterator.HasNext(); {
+		vtx := iterator.Next()
+		network.indexes[vtx] = len(network.vtxs)
+		network.vtxs = append(network.vtxs, vtx)
+	}
+	network.outcoming = make([][]int, len(network.vtxs))
+	for from, vtx := range network.vtxs {
+		for _, end := range vtx.ownOutcomingEnds() {
+			if end.vertex == vtx {
+				continue
+			}
+			to := network.indexes[end.vertex]
+			edgeCapacity := capacity(end.edge)
+			reverseCapacity := 0.0
+			if end.edge.undirected {
+				reverseCapacity = edgeCapacity
+			}
+			network.outcoming[from] = append(network.outcoming[from], len(network.arcs))
+			network.arcs = append(network.arcs, flowArc{to: to, capacity: edgeCapacity, residual: edgeCapacity, reverse: len(network.arcs) + 1, edge: end.edge, forward: true})
+			network.outcoming[to] = append(network.outcoming[to], len(network.arcs))
+			network.arcs = append(network.arcs, flowArc{to: from, capacity: reverseCapacity, residual: reverseCapacity, reverse: len(network.arcs) - 1, edge: end.edge})
+		}
+	}
+	return network
+}
+
+func (fn *flowNetwork) push(arc int, amount float64) {
+	fn.arcs[arc].residual -= amount
+	fn.arcs[fn.arcs[arc].reverse].residual += amount
+}
+
+// returns amount of arcs with spare capacity from the source to each vertex, -1 for unreachable vertexes
+func (fn *flowNetwork) levels() []int {
+	levels := make([]int, len(fn.vtxs))
+	for index := range levels {
+		levels[index] = -1
+	}
+	levels[0] = 0
+	for check := []int{0}; len(check) > 0; check = check[1:] {
+		for _, arc := range fn.outcoming[check[0]] {
+			if to := fn.arcs[arc].to; fn.arcs[arc].residual > 0 && levels[to] < 0 {
+				levels[to] = levels[check[0]] + 1
+				check = append(check, to)
+			}
+		}
+	}
+	return levels
+}
+
+func (fn *flowNetwork) edmondsKarp(sink int) (total float64) {
+	for {
+		// arcs vertexes were reached by during breadth-first search
+		reachedBy := make([]int, len(fn.vtxs))
+		for index := range reachedBy {
+			reachedBy[index] = -1
+		}
+		for check := []int{0}; len(check) > 0 && reachedBy[sink] < 0; check = check[1:] {
+			for _, arc := range fn.outcoming[check[0]] {
+				if to := fn.arcs[arc].to; fn.arcs[arc].residual > 0 && to != 0 && reachedBy[to] < 0 {
+					reachedBy[to] = arc
+					check = append(check, to)
+				}
+			}
+		}
+		if reachedBy[sink] < 0 {
+			return
+		}
+
+		amount := fn.arcs[reachedBy[sink]].residual
+		for vtx := sink; vtx != 0; vtx = fn.arcs[fn.arcs[reachedBy[vtx]].reverse].to {
+			if residual := fn.arcs[reachedBy[vtx]].residual; residual < amount {
+				amount = residual
+			}
+		}
+		for vtx := sink; vtx != 0; vtx = fn.arcs[fn.arcs[reachedBy[vtx]].reverse].to {
+			fn.push(reachedBy[vtx], amount)
+		}
+		total += amount
+	}
+}
+
+func (fn *flowNetwork) dinic(sink int) (total float64) {
+	for {
+		levels := fn.levels()
+		if levels[sink] < 0 {
+			return
+		}
+		// position of the next arc to try for each vertex, arcs before it are saturated or lead to dead ends
+		next := make([]int, len(fn.vtxs))
+		var augment func(vtx int, limit float64) float64
+		augment = func(vtx int, limit float64) float64 {
+			if vtx == sink {
+				return limit
+			}
+			for ; next[vtx] < len(fn.outcoming[vtx]); next[vtx]++ {
+				arc := fn.outcoming[vtx][next[vtx]]
+				to, residual := fn.arcs[arc].to, fn.arcs[arc].residual
+				if residual <= 0 || levels[to] != levels[vtx]+1 {
+					continue
+				}
+				if residual > limit {
+					residual = limit
+				}
+				if pushed := augment(to, residual); pushed > 0 {
+					fn.push(arc, pushed)
+					return pushed
+				}
+			}
+			return 0
+		}
+		for {
+			pushed := augment(0, math.Inf(1))
+			if pushed <= 0 {
+				break
+			}
+			total += pushed
+		}
+	}
+}
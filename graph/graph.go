@@ -1,5 +1,7 @@
 package graph
 
+import "sync"
+
 type (
 	DataPredicate       func(d interface{}) bool
 	VertexPredicate     func(vtx *Vertex) bool
@@ -7,6 +9,7 @@ type (
 	Action              func(vtx *Vertex) error
 	GroupEdgesAction    func(groupKey []byte, edges []*Edge) error
 	GroupVertexesAction func(groupKey []byte, vtxs []*Vertex) error
+	PathAction          func(path Path) error
 	EdgesGrouper        func(edge *Edge) []byte
 	VertexesGrouper     func(vtx *Vertex) []byte
 	VertexSelector      func(vtx *Vertex) bool
@@ -22,12 +25,25 @@ type (
 	}
 )
 
+// Defines which repetitions are allowed on a path over edges
+type PathSemantics int
+
+const (
+	// vertexes and edges can be repeated on the path
+	Walk = PathSemantics(iota)
+	// edges can't be repeated on the path, vertexes can
+	Trail
+	// neither vertexes nor edges can be repeated on the path
+	SimplePath
+)
+
 func GoOverEdge(edgeSelector EdgePredicate) PathOverEdge {
 	return PathOverEdge{selectors: []EdgePredicate{edgeSelector}}
 }
 
 type PathOverEdge struct {
 	selectors []EdgePredicate
+	semantics PathSemantics
 }
 
 func (poe PathOverEdge) GoOverEdge(edgeSelector EdgePredicate) PathOverEdge {
@@ -35,6 +51,12 @@ func (poe PathOverEdge) GoOverEdge(edgeSelector EdgePredicate) PathOverEdge {
 	return poe
 }
 
+// Sets semantics of the path, by default it is a `Walk`
+func (poe PathOverEdge) WithSemantics(semantics PathSemantics) PathOverEdge {
+	poe.semantics = semantics
+	return poe
+}
+
 func (poe PathOverEdge) GroupVertexesWith(vtxGrouper VertexesGrouper) CompleteGrouperOverEdgesPath {
 	return CompleteGrouperOverEdgesPath{pathOverEdge: poe, vtxGrouper: vtxGrouper}
 }
@@ -45,7 +67,7 @@ func (poe PathOverEdge) ExistVertexesWith(vtxSelector VertexSelector) CompleteSe
 
 type CompleteSelectorOverEdgesPath struct {
 	pathOverEdge PathOverEdge
-	vtxSelector VertexSelector
+	vtxSelector  VertexSelector
 }
 
 type CompleteGrouperOverEdgesPath struct {
@@ -55,27 +77,77 @@ type CompleteGrouperOverEdgesPath struct {
 
 func EdgeAttributeEqualsTo(data interface{}) EdgePredicate {
 	return func(edge *Edge) bool {
-		return edge.attributes == data
+		return edge.Attributes() == data
 	}
 }
 
+// Edge between two vertexes, the same value is shared by both of them
 type Edge struct {
-	vertex     *Vertex
+	from, to   *Vertex
 	attributes interface{}
+	undirected bool
+	// epoch attributes were set at and previous attributes kept for snapshots
+	modified int
+	versions []attributesVersion
+}
+
+// Vertex the edge starts at
+func (edge *Edge) From() *Vertex {
+	return edge.from
+}
+
+// Vertex the edge ends at
+func (edge *Edge) To() *Vertex {
+	return edge.to
 }
 
-func (edge *Edge) Vertex() *Vertex {
-	return edge.vertex
+// Returns end of the edge opposite to provided vertex
+func (edge *Edge) Other(vtx *Vertex) *Vertex {
+	if edge.from == vtx {
+		return edge.to
+	}
+	return edge.from
+}
+
+// Reports if edge was created with `EdgeTo` or `EdgeToWith`, undirected edges can be passed in both directions
+func (edge *Edge) Directed() bool {
+	return !edge.undirected
 }
 
 func (edge *Edge) Attributes() interface{} {
+	rlock(edge.from.guard)
+	defer runlock(edge.from.guard)
 	return edge.attributes
 }
 
+// Replaces attributes of the edge, change is visible from both of its vertexes
+func (edge *Edge) SetAttributes(attributes interface{}) {
+	edge.from.mutable()
+	lock(edge.from.guard)
+	defer unlock(edge.from.guard)
+	edge.preserve()
+	edge.attributes = attributes
+}
+
+// edge as it is seen from one of its vertexes
+type edgeEnd struct {
+	edge *Edge
+	// vertex on the other end of the edge
+	vertex *Vertex
+}
+
 type Vertex struct {
 	incoming, outcoming EdgeSet
 	adjacent            VertexSet
 	data                interface{}
+	graph               *Graph
+	// lock of the concurrent graph the vertex was added to, it is kept after the vertex is detached
+	guard *sync.RWMutex
+	// epoch edges were changed at and previous edges kept for snapshots
+	modified int
+	versions []vertexVersion
+	// not `nil` for vertexes of a snapshot
+	view *vertexView
 }
 
 func VertexWith(data interface{}) *Vertex {
@@ -90,12 +162,26 @@ func (vtx *Vertex) Data() interface{} {
 	return vtx.data
 }
 
+// Graph the vertex belongs to or `nil` if it is a free vertex
+func (vtx *Vertex) Graph() *Graph {
+	rlock(vtx.guard)
+	defer runlock(vtx.guard)
+	return vtx.graph
+}
+
 func (fromVtx *Vertex) EdgesTo(toVtx *Vertex) EdgeSet {
+	fromVtx.materialize()
+	rlock(fromVtx.guard)
+	defer runlock(fromVtx.guard)
+	return fromVtx.edgesTo(toVtx)
+}
+
+func (fromVtx *Vertex) edgesTo(toVtx *Vertex) EdgeSet {
 	es := NewEdgeSet()
 	for iterator := fromVtx.outcoming.Iterator(); iterator.HasNext(); {
-		edge := iterator.Next()
-		if edge.vertex == toVtx {
-			es.put(edge)
+		end := iterator.nextEnd()
+		if end.vertex == toVtx {
+			es.put(end)
 		}
 	}
 	return es
@@ -107,11 +193,7 @@ func (fromVtx *Vertex) EdgeTo(toVtx *Vertex) *Vertex {
 }
 
 func (fromVtx *Vertex) EdgeToWith(toVtx *Vertex, attributes interface{}) *Vertex {
-	fromVtx.outcoming.put(&Edge{vertex: toVtx, attributes: attributes})
-	fromVtx.adjacent.put(toVtx)
-
-	toVtx.incoming.put(&Edge{vertex: fromVtx, attributes: attributes})
-	toVtx.adjacent.put(fromVtx)
+	link(&Edge{from: fromVtx, to: toVtx, attributes: attributes})
 	return fromVtx
 }
 
@@ -120,26 +202,185 @@ func (oneVtx *Vertex) Edge(anotherVtx *Vertex) *Vertex {
 	return oneVtx
 }
 
+// Creates single undirected edge, it is outcoming and incoming one for both vertexes
 func (oneVtx *Vertex) EdgeWith(anotherVtx *Vertex, attributes interface{}) *Vertex {
-	oneVtx.EdgeToWith(anotherVtx, attributes)
-	anotherVtx.EdgeToWith(oneVtx, attributes)
+	link(&Edge{from: oneVtx, to: anotherVtx, attributes: attributes, undirected: true})
 	return oneVtx
 }
 
+func link(edge *Edge) {
+	edge.from.mutable()
+	edge.to.mutable()
+	guard := guardOf(edge.from, edge.to)
+	lock(guard)
+	defer unlock(guard)
+	g := joinGraphs(edge.from, edge.to)
+	edge.from.preserve()
+	edge.to.preserve()
+
+	edge.from.outcoming.put(edgeEnd{edge: edge, vertex: edge.to})
+	edge.from.adjacent.put(edge.to)
+
+	edge.to.incoming.put(edgeEnd{edge: edge, vertex: edge.from})
+	edge.to.adjacent.put(edge.from)
+
+	if edge.undirected && edge.from != edge.to {
+		edge.to.outcoming.put(edgeEnd{edge: edge, vertex: edge.from})
+		edge.from.incoming.put(edgeEnd{edge: edge, vertex: edge.to})
+	}
+
+	if g != nil {
+		g.preserve()
+		g.edges.put(edgeEnd{edge: edge, vertex: edge.to})
+		edge.modified = g.epoch
+	}
+}
+
+// Removes edge from both vertexes it connects, edge can be either outcoming or incoming one
+func (vtx *Vertex) RemoveEdge(edge *Edge) *Vertex {
+	vtx.mutable()
+	lock(vtx.guard)
+	defer unlock(vtx.guard)
+	vtx.removeEdge(edge)
+	return vtx
+}
+
+func (vtx *Vertex) removeEdge(edge *Edge) {
+	if edge.from != vtx && edge.to != vtx {
+		return
+	}
+	edge.from.preserve()
+	edge.to.preserve()
+	if !edge.from.outcoming.remove(edge) {
+		return
+	}
+	edge.to.incoming.remove(edge)
+	if edge.undirected && edge.from != edge.to {
+		edge.to.outcoming.remove(edge)
+		edge.from.incoming.remove(edge)
+	}
+	if !edge.from.linkedWith(edge.to) {
+		edge.from.adjacent.remove(edge.to)
+		edge.to.adjacent.remove(edge.from)
+	}
+	if vtx.graph != nil {
+		vtx.graph.preserve()
+		vtx.graph.edges.remove(edge)
+	}
+}
+
+// Removes all outcoming edges to provided vertex
+func (fromVtx *Vertex) RemoveEdgesTo(toVtx *Vertex) *Vertex {
+	fromVtx.mutable()
+	lock(fromVtx.guard)
+	defer unlock(fromVtx.guard)
+	for iterator := fromVtx.edgesTo(toVtx).Iterator(); iterator.HasNext(); {
+		fromVtx.removeEdge(iterator.Next())
+	}
+	return fromVtx
+}
+
+// Removes all edges of the vertex and excludes it from the graph it belongs to
+func (vtx *Vertex) Detach() *Vertex {
+	vtx.mutable()
+	lock(vtx.guard)
+	defer unlock(vtx.guard)
+	for _, es := range []EdgeSet{vtx.outcoming, vtx.incoming} {
+		edges := NewEdgeSet().Merge(es)
+		for iterator := edges.Iterator(); iterator.HasNext(); {
+			vtx.removeEdge(iterator.Next())
+		}
+	}
+	if vtx.graph != nil {
+		// edges added to the free vertex later must not change versions snapshots read
+		vtx.preserve()
+		vtx.graph.preserve()
+		vtx.graph.vertexes.remove(vtx)
+		vtx.graph = nil
+	}
+	return vtx
+}
+
+// reports if there is at least one edge between vertexes in any direction
+func (vtx *Vertex) linkedWith(anotherVtx *Vertex) bool {
+	// each edge between vertexes is known to both of them, so edges of the one with fewer edges are enough
+	if anotherVtx.outcoming.Len()+anotherVtx.incoming.Len() < vtx.outcoming.Len()+vtx.incoming.Len() {
+		vtx, anotherVtx = anotherVtx, vtx
+	}
+	for _, es := range []EdgeSet{vtx.outcoming, vtx.incoming} {
+		for iterator := es.Iterator(); iterator.HasNext(); {
+			if iterator.nextEnd().vertex == anotherVtx {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Outcoming edges of the vertex, for vertexes of a concurrent graph it is a copy
 func (vtx *Vertex) Outcoming() EdgeSet {
 	return vtx.OutcomingWhich(nil)
 }
 
 func (vtx *Vertex) OutcomingWhich(predicate EdgePredicate) EdgeSet {
-	return vtx.directedWhich(vtx.outcoming, predicate)
+	return vtx.directedWhich(vtx.edgeSet(OutcomingDirection), predicate)
 }
 
+// Incoming edges of the vertex, for vertexes of a concurrent graph it is a copy
 func (vtx *Vertex) Incoming() EdgeSet {
-	return vtx.incoming
+	return vtx.IncomingWhich(nil)
 }
 
 func (vtx *Vertex) IncomingWhich(predicate EdgePredicate) EdgeSet {
-	return vtx.directedWhich(vtx.incoming, predicate)
+	return vtx.directedWhich(vtx.edgeSet(IncomingDirection), predicate)
+}
+
+// returns outcoming or incoming edges of the vertex or their copy if the vertex is guarded
+func (vtx *Vertex) edgeSet(direction Direction) EdgeSet {
+	vtx.materialize()
+	rlock(vtx.guard)
+	defer runlock(vtx.guard)
+	es := vtx.outcoming
+	if direction == IncomingDirection {
+		es = vtx.incoming
+	}
+	if vtx.guard == nil {
+		return es
+	}
+	return NewEdgeSet().Merge(es)
+}
+
+// outcoming edges together with vertexes they lead to
+func (vtx *Vertex) outcomingEnds() []edgeEnd {
+	vtx.materialize()
+	rlock(vtx.guard)
+	defer runlock(vtx.guard)
+	return vtx.outcoming.ends()
+}
+
+// outcoming edges that start at the vertex, so undirected edge that is outcoming for both of its vertexes
+// is returned only for its `From` vertex and each edge is taken once when edges of several vertexes are combined
+func (vtx *Vertex) ownOutcomingEnds() []edgeEnd {
+	return ownEnds(vtx, vtx.outcomingEnds())
+}
+
+// keeps outcoming edges of the vertex that start at it
+func ownEnds(vtx *Vertex, ends []edgeEnd) []edgeEnd {
+	own := ends[:0]
+	for _, end := range ends {
+		if end.edge.from == vtx {
+			own = append(own, end)
+		}
+	}
+	return own
+}
+
+// incoming edges together with vertexes they come from
+func (vtx *Vertex) incomingEnds() []edgeEnd {
+	vtx.materialize()
+	rlock(vtx.guard)
+	defer runlock(vtx.guard)
+	return vtx.incoming.ends()
 }
 
 func (vtx *Vertex) directedWhich(es EdgeSet, predicate EdgePredicate) EdgeSet {
@@ -148,16 +389,23 @@ func (vtx *Vertex) directedWhich(es EdgeSet, predicate EdgePredicate) EdgeSet {
 	}
 	esw := NewEdgeSet()
 	for iterator := es.Iterator(); iterator.HasNext(); {
-		edge := iterator.Next()
-		if predicate(edge) {
-			esw.put(edge)
+		end := iterator.nextEnd()
+		if predicate(end.edge) {
+			esw.put(end)
 		}
 	}
 	return esw
 }
 
+// Vertexes connected with the vertex by an edge in any direction, for vertexes of a concurrent graph it is a copy
 func (vtx *Vertex) Adjacent() VertexSet {
-	return vtx.adjacent
+	vtx.materialize()
+	if vtx.guard == nil {
+		return vtx.adjacent
+	}
+	vtx.guard.RLock()
+	defer vtx.guard.RUnlock()
+	return vtx.adjacent.copy()
 }
 
 func (vtx *Vertex) GroupedBy(defineGroup EdgesGrouper) (groups []GroupedEdges) {
@@ -185,16 +433,16 @@ func (vtx *Vertex) GroupedIncomingBy(defineGroup EdgesGrouper) (groups []Grouped
 }
 
 func (vtx *Vertex) GroupBy(defineGroup EdgesGrouper, action GroupEdgesAction) error {
-	grouped := groupEdgesInto(groupEdges(vtx.outcoming, defineGroup), vtx.incoming, defineGroup)
+	grouped := groupEdgesInto(groupEdges(vtx.Outcoming(), defineGroup), vtx.Incoming(), defineGroup)
 	return applyEdgeGroupAction(grouped, action)
 }
 
 func (vtx *Vertex) GroupOutcomingBy(defineGroup EdgesGrouper, action GroupEdgesAction) error {
-	return applyEdgeGroupAction(groupEdges(vtx.outcoming, defineGroup), action)
+	return applyEdgeGroupAction(groupEdges(vtx.Outcoming(), defineGroup), action)
 }
 
 func (vtx *Vertex) GroupIncomingBy(defineGroup EdgesGrouper, action GroupEdgesAction) error {
-	return applyEdgeGroupAction(groupEdges(vtx.incoming, defineGroup), action)
+	return applyEdgeGroupAction(groupEdges(vtx.Incoming(), defineGroup), action)
 }
 
 func groupEdges(es EdgeSet, defineGroup EdgesGrouper) map[string][]*Edge {
@@ -212,45 +460,101 @@ func groupEdgesInto(grouped map[string][]*Edge, es EdgeSet, defineGroup EdgesGro
 }
 
 func (vtx *Vertex) GroupVertexes(pathGrouper CompleteGrouperOverEdgesPath) []GroupedVertexes {
-	// TODO: get rid of cycling over closed graph paths
-	currentVtxs := NewVertexSet(vtx)
-	for _, pathSelector := range pathGrouper.pathOverEdge.selectors {
-		nextVtxs := NewVertexSet()
-		for currentVtxIterator := currentVtxs.Iterator(); currentVtxIterator.HasNext(); {
-			currentVtx := currentVtxIterator.Next()
-			for _, iterator := range []EdgeSetIterator{currentVtx.incoming.Iterator(), currentVtx.outcoming.Iterator()} {
-				for iterator.HasNext() {
-					edge := iterator.Next()
-					if pathSelector(edge) {
-						nextVtxs.put(edge.vertex)
-					}
+	return vtx.goOver(pathGrouper.pathOverEdge).GroupedBy(pathGrouper.vtxGrouper)
+}
+
+func (vtx *Vertex) ExistVertexes(pathSelector CompleteSelectorOverEdgesPath) bool {
+	return vtx.goOver(pathSelector.pathOverEdge).ExistsBy(pathSelector.vtxSelector)
+}
+
+// returns vertexes where paths over edges started at vertex end
+func (vtx *Vertex) goOver(poe PathOverEdge) VertexSet {
+	if poe.semantics == Walk {
+		return vtx.walkOver(poe.selectors)
+	}
+
+	currentSteps := []*pathStep{{vtx: vtx}}
+	for _, pathSelector := range poe.selectors {
+		var nextSteps []*pathStep
+		for _, currentStep := range currentSteps {
+			for _, end := range currentStep.vtx.incidentEnds() {
+				if !pathSelector(end.edge) || currentStep.passed(end.edge) {
+					continue
+				}
+				if poe.semantics == SimplePath && currentStep.visited(end.vertex) {
+					continue
 				}
+				nextSteps = append(nextSteps, &pathStep{vtx: end.vertex, edge: end.edge, previous: currentStep})
 			}
 		}
-		currentVtxs = nextVtxs
+		currentSteps = nextSteps
+	}
+
+	currentVtxs := NewVertexSet()
+	for _, currentStep := range currentSteps {
+		currentVtxs.put(currentStep.vtx)
 	}
-	return currentVtxs.GroupedBy(pathGrouper.vtxGrouper)
+	return currentVtxs
 }
 
-func (vtx *Vertex) ExistVertexes(pathSelector CompleteSelectorOverEdgesPath) bool {
-	// TODO: get rid of cycling over closed graph paths
+// all paths are merged at each step, so it works with any graph, but it allows to return back over the same edge
+func (vtx *Vertex) walkOver(selectors []EdgePredicate) VertexSet {
 	currentVtxs := NewVertexSet(vtx)
-	for _, pathSelector := range pathSelector.pathOverEdge.selectors {
+	for _, pathSelector := range selectors {
 		nextVtxs := NewVertexSet()
 		for currentVtxIterator := currentVtxs.Iterator(); currentVtxIterator.HasNext(); {
 			currentVtx := currentVtxIterator.Next()
-			for _, iterator := range []EdgeSetIterator{currentVtx.incoming.Iterator(), currentVtx.outcoming.Iterator()} {
-				for iterator.HasNext() {
-					edge := iterator.Next()
-					if pathSelector(edge) {
-						nextVtxs.put(edge.vertex)
-					}
+			for _, end := range currentVtx.incidentEnds() {
+				if pathSelector(end.edge) {
+					nextVtxs.put(end.vertex)
 				}
 			}
 		}
 		currentVtxs = nextVtxs
 	}
-	return currentVtxs.ExistsBy(pathSelector.vtxSelector)
+	return currentVtxs
+}
+
+// returns all edges of the vertex, each undirected edge is returned only once
+func (vtx *Vertex) incidentEnds() []edgeEnd {
+	vtx.materialize()
+	rlock(vtx.guard)
+	defer runlock(vtx.guard)
+	var ends []edgeEnd
+	for iterator := vtx.incoming.Iterator(); iterator.HasNext(); {
+		if end := iterator.nextEnd(); !end.edge.undirected {
+			ends = append(ends, end)
+		}
+	}
+	for iterator := vtx.outcoming.Iterator(); iterator.HasNext(); {
+		ends = append(ends, iterator.nextEnd())
+	}
+	return ends
+}
+
+// Single step of the path that remembers how it was reached
+type pathStep struct {
+	vtx      *Vertex
+	edge     *Edge
+	previous *pathStep
+}
+
+func (ps *pathStep) passed(edge *Edge) bool {
+	for step := ps; step != nil; step = step.previous {
+		if step.edge == edge {
+			return true
+		}
+	}
+	return false
+}
+
+func (ps *pathStep) visited(vtx *Vertex) bool {
+	for step := ps; step != nil; step = step.previous {
+		if step.vtx == vtx {
+			return true
+		}
+	}
+	return false
 }
 
 func applyEdgeGroupAction(grouped map[string][]*Edge, action GroupEdgesAction) error {
@@ -265,17 +569,29 @@ func applyEdgeGroupAction(grouped map[string][]*Edge, action GroupEdgesAction) e
 type VertexSet struct {
 	set   map[*Vertex]int
 	order map[int]*Vertex
+	// positions of removed vertexes, they are skipped by iterators until the set is compacted
+	removed map[int]bool
 }
 
 func NewVertexSet(vtxs ...*Vertex) (vs VertexSet) {
 	vs.set = map[*Vertex]int{}
 	vs.order = map[int]*Vertex{}
+	vs.removed = map[int]bool{}
 	for _, vtx := range vtxs {
 		vs.put(vtx)
 	}
 	return
 }
 
+// returns new set with the same vertexes in the same order
+func (vs VertexSet) copy() VertexSet {
+	copied := NewVertexSet()
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		copied.put(iterator.Next())
+	}
+	return copied
+}
+
 func (vs VertexSet) Contains(vtx *Vertex) (found bool) {
 	_, found = vs.set[vtx]
 	return
@@ -321,27 +637,109 @@ func (vs VertexSet) ExistsBy(vtxSelector VertexSelector) bool {
 	return false
 }
 
+// Groups are passed to the action in order their first vertexes have in the set, vertexes of a group keep their order
 func (vs VertexSet) GroupBy(defineGroup VertexesGrouper, action GroupVertexesAction) error {
+	var gkeys []string
 	grouped := map[string][]*Vertex{}
-	for vtx := range vs.set {
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
 		gkey := string(defineGroup(vtx))
+		if _, found := grouped[gkey]; !found {
+			gkeys = append(gkeys, gkey)
+		}
 		grouped[gkey] = append(grouped[gkey], vtx)
 	}
-	return applyVertexGroupAction(grouped, action)
+	return applyVertexGroupAction(gkeys, grouped, action)
 }
 
-func applyVertexGroupAction(grouped map[string][]*Vertex, action GroupVertexesAction) error {
-	for gkey, vtxs := range grouped {
-		if err := action([]byte(gkey), vtxs); err != nil {
+func applyVertexGroupAction(gkeys []string, grouped map[string][]*Vertex, action GroupVertexesAction) error {
+	for _, gkey := range gkeys {
+		if err := action([]byte(gkey), grouped[gkey]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// Returns new set with vertexes of both sets, vertexes of the set go first
+func (vs VertexSet) Union(another VertexSet) VertexSet {
+	union := vs.copy()
+	for iterator := another.Iterator(); iterator.HasNext(); {
+		union.put(iterator.Next())
+	}
+	return union
+}
+
+// Returns new set with vertexes that are in both sets in order of the set
+func (vs VertexSet) Intersect(another VertexSet) VertexSet {
+	return vs.filter(another.Contains)
+}
+
+// Returns new set with vertexes of the set that are not in another one
+func (vs VertexSet) Minus(another VertexSet) VertexSet {
+	return vs.filter(func(vtx *Vertex) bool { return !another.Contains(vtx) })
+}
+
+// Returns new set with vertexes that are only in one of the sets, vertexes of the set go first
+func (vs VertexSet) SymmetricDifference(another VertexSet) VertexSet {
+	return vs.Minus(another).Union(another.Minus(vs))
+}
+
+func (vs VertexSet) filter(selector VertexSelector) VertexSet {
+	filtered := NewVertexSet()
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		if vtx := iterator.Next(); selector(vtx) {
+			filtered.put(vtx)
+		}
+	}
+	return filtered
+}
+
 func (vs VertexSet) put(vtx *Vertex) {
-	vs.set[vtx] = len(vs.set)
-	vs.order[len(vs.order)] = vtx
+	if vs.Contains(vtx) {
+		return
+	}
+	position := vs.end()
+	vs.set[vtx] = position
+	vs.order[position] = vtx
+}
+
+// removes vertex and leaves its position empty, the set is compacted when empty positions outnumber vertexes
+func (vs VertexSet) remove(vtx *Vertex) bool {
+	index, found := vs.set[vtx]
+	if !found {
+		return false
+	}
+	delete(vs.set, vtx)
+	delete(vs.order, index)
+	vs.removed[index] = true
+	if len(vs.removed) > len(vs.set) {
+		vs.compact()
+	}
+	return true
+}
+
+// moves vertexes to empty positions keeping their order, so positions are dense again
+func (vs VertexSet) compact() {
+	position, end := 0, vs.end()
+	for index := 0; index < end; index++ {
+		if vs.removed[index] {
+			delete(vs.removed, index)
+			continue
+		}
+		if index != position {
+			vtx := vs.order[index]
+			delete(vs.order, index)
+			vs.order[position] = vtx
+			vs.set[vtx] = position
+		}
+		position++
+	}
+}
+
+// position after the last vertex
+func (vs VertexSet) end() int {
+	return len(vs.order) + len(vs.removed)
 }
 
 func (vs VertexSet) Iterator() VertexSetIterator {
@@ -354,31 +752,50 @@ type VertexSetIterator struct {
 }
 
 func (vi VertexSetIterator) HasNext() bool {
-	return vi.current < len(vi.vs.order)
+	vi.skipRemoved()
+	return vi.current < vi.vs.end()
 }
 
 func (vi *VertexSetIterator) Next() (vtx *Vertex) {
+	vi.skipRemoved()
 	vtx = vi.vs.order[vi.current]
 	vi.current++
 	return
 }
 
+func (vi *VertexSetIterator) skipRemoved() {
+	for vi.vs.removed[vi.current] {
+		vi.current++
+	}
+}
+
 func NewEdgeSet(edges ...*Edge) (es EdgeSet) {
-	es.container = map[int]*Edge{}
+	es.container = map[int]edgeEnd{}
+	es.index = map[*Edge]int{}
+	es.removed = map[int]bool{}
 	for _, edge := range edges {
-		es.put(edge)
+		es.put(edgeEnd{edge: edge, vertex: edge.to})
 	}
 	return
 }
 
 type EdgeSet struct {
-	container map[int]*Edge
+	container map[int]edgeEnd
+	// position of each edge in the container, the first one if the edge was put several times
+	index map[*Edge]int
+	// positions of removed edges, they are skipped by iterators until the set is compacted
+	removed map[int]bool
 }
 
 func (es EdgeSet) Len() int {
 	return len(es.container)
 }
 
+func (es EdgeSet) Contains(edge *Edge) (found bool) {
+	_, found = es.index[edge]
+	return
+}
+
 func (es EdgeSet) GroupedBy(defineGroup EdgesGrouper) (groups []GroupedEdges) {
 	_ = es.GroupBy(defineGroup, func(groupKey []byte, edges []*Edge) error {
 		groups = append(groups, GroupedEdges{GroupKey: groupKey, Edges: edges})
@@ -391,10 +808,11 @@ func (es EdgeSet) GroupBy(defineGroup EdgesGrouper, action GroupEdgesAction) err
 	return applyEdgeGroupAction(groupEdges(es, defineGroup), action)
 }
 
+// Vertexes on the other end of edges, for outcoming edges these are vertexes edges lead to
+// and for incoming edges these are vertexes edges come from
 func (es EdgeSet) Vertexes() (vtxs []*Vertex) {
 	for iterator := es.Iterator(); iterator.HasNext(); {
-		edge := iterator.Next()
-		vtxs = append(vtxs, edge.Vertex())
+		vtxs = append(vtxs, iterator.nextEnd().vertex)
 	}
 	return
 }
@@ -402,14 +820,76 @@ func (es EdgeSet) Vertexes() (vtxs []*Vertex) {
 func (es EdgeSet) VertexesSet() VertexSet {
 	vs := NewVertexSet()
 	for iterator := es.Iterator(); iterator.HasNext(); {
-		edge := iterator.Next()
-		vs.put(edge.Vertex())
+		vs.put(iterator.nextEnd().vertex)
 	}
 	return vs
 }
 
-func (es EdgeSet) put(edge *Edge) {
-	es.container[len(es.container)] = edge
+// edges in order they were added together with vertexes they lead to
+func (es EdgeSet) ends() []edgeEnd {
+	ends := make([]edgeEnd, 0, len(es.container))
+	for iterator := es.Iterator(); iterator.HasNext(); {
+		ends = append(ends, iterator.nextEnd())
+	}
+	return ends
+}
+
+func (es EdgeSet) put(end edgeEnd) {
+	position := es.end()
+	if !es.Contains(end.edge) {
+		es.index[end.edge] = position
+	}
+	es.container[position] = end
+}
+
+// removes the first copy of the edge and leaves its position empty,
+// the set is compacted when empty positions outnumber edges
+func (es EdgeSet) remove(edge *Edge) bool {
+	index, found := es.index[edge]
+	if !found {
+		return false
+	}
+	delete(es.index, edge)
+	delete(es.container, index)
+	es.removed[index] = true
+	if len(es.container) > len(es.index) {
+		// only a set with repeated edges has more edges than positions in the index
+		for position, end := index+1, es.end(); position < end; position++ {
+			if next, found := es.container[position]; found && next.edge == edge {
+				es.index[edge] = position
+				break
+			}
+		}
+	}
+	if len(es.removed) > len(es.container) {
+		es.compact()
+	}
+	return true
+}
+
+// moves edges to empty positions keeping their order, so positions are dense again
+func (es EdgeSet) compact() {
+	position, end := 0, es.end()
+	for index := 0; index < end; index++ {
+		if es.removed[index] {
+			delete(es.removed, index)
+			continue
+		}
+		if index != position {
+			moved := es.container[index]
+			delete(es.container, index)
+			es.container[position] = moved
+			if es.index[moved.edge] == index {
+				es.index[moved.edge] = position
+			}
+		}
+		position++
+	}
+}
+
+// position after the last edge
+func (es EdgeSet) end() int {
+	return len(es.container) + len(es.removed)
 }
 
 func (es EdgeSet) Iterator() EdgeSetIterator {
@@ -418,12 +898,10 @@ func (es EdgeSet) Iterator() EdgeSetIterator {
 
 func (es EdgeSet) Merge(withEs EdgeSet) EdgeSet {
 	merged := NewEdgeSet()
-	for order, edge := range es.container {
-		merged.container[order] = edge
-	}
-	delta := len(merged.container)
-	for order, edge := range withEs.container {
-		merged.container[order+delta] = edge
+	for _, set := range []EdgeSet{es, withEs} {
+		for iterator := set.Iterator(); iterator.HasNext(); {
+			merged.put(iterator.nextEnd())
+		}
 	}
 	return merged
 }
@@ -434,11 +912,23 @@ type EdgeSetIterator struct {
 }
 
 func (ei EdgeSetIterator) HasNext() bool {
-	return ei.current < len(ei.es.container)
+	ei.skipRemoved()
+	return ei.current < ei.es.end()
 }
 
-func (ei *EdgeSetIterator) Next() (edge *Edge) {
-	edge = ei.es.container[ei.current]
+func (ei *EdgeSetIterator) Next() *Edge {
+	return ei.nextEnd().edge
+}
+
+func (ei *EdgeSetIterator) nextEnd() (end edgeEnd) {
+	ei.skipRemoved()
+	end = ei.es.container[ei.current]
 	ei.current++
 	return
 }
+
+func (ei *EdgeSetIterator) skipRemoved() {
+	for ei.es.removed[ei.current] {
+		ei.current++
+	}
+}
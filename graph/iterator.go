@@ -8,54 +8,157 @@ type GraphIterator interface {
 	Next() *Vertex
 }
 
-// Depth-First Search implementation structs
-type dfSearcherWrapper struct {
-	searcher *dfSearcher
+// Iterator that remembers how each of returned vertexes was reached
+type pathIterator interface {
+	GraphIterator
+	// returns path from the start vertex to already returned vertex
+	path(vtx *Vertex) Path
 }
 
-func (dfsw *dfSearcherWrapper) Next() *Vertex {
-	return dfsw.searcher.Next()
+// edges by which vertexes were reached for the first time
+type reachedBy map[*Vertex]*Edge
+
+func (rb reachedBy) path(vtx *Vertex) Path {
+	path := pathTo(vtx, rb, 0)
+	path.cost = float64(path.Len())
+	return path
+}
+
+// Discovery and finish moments of vertex visited by depth-first search
+type VisitTime struct {
+	// moment vertex was reached for the first time
+	Discovered int
+	// moment all vertexes reachable from the vertex were visited
+	Finished int
+}
+
+// Depth-First Search implementation structs
+type dfFrame struct {
+	vtx   *Vertex
+	ends  []edgeEnd
+	index int
+	depth int
 }
 
 type dfSearcher struct {
-	vtx      *Vertex
-	vtxs     []*Vertex
-	index    int
-	previous *dfSearcher
-	wrapper  *dfSearcherWrapper
+	traversal
+	preOrder bool
+	stack    []dfFrame
+	// vertex that will be returned by the following call to `Next`
+	next    *Vertex
+	visited VertexSet
+	reachedBy
+	clock int
+	times map[*Vertex]VisitTime
 }
 
-func (dfs *dfSearcher) Next() *Vertex {
-	if dfs.index < len(dfs.vtxs) {
-		vtx := dfs.vtxs[dfs.index]
-		dfs.index++
-		dfs.wrapper.searcher = &dfSearcher{vtx: vtx, previous: dfs, vtxs: vtx.Outcoming().Vertexes(), wrapper: dfs.wrapper}
-		return dfs.wrapper.Next()
+func newDFSearcher(vtx *Vertex, t traversal, preOrder bool) *dfSearcher {
+	dfs := &dfSearcher{traversal: t, preOrder: preOrder, visited: NewVertexSet(), reachedBy: reachedBy{}, times: map[*Vertex]VisitTime{}}
+	dfs.discover(vtx, 0)
+	if preOrder {
+		dfs.next = vtx
 	} else {
-		vtx := dfs.vtx
-		if dfs.previous != nil {
-			dfs.wrapper.searcher = dfs.previous
-		} else {
-			dfs.wrapper.searcher = nil
+		dfs.next = dfs.advance()
+	}
+	return dfs
+}
+
+func (dfs *dfSearcher) Next() *Vertex {
+	vtx := dfs.next
+	dfs.next = dfs.advance()
+	return vtx
+}
+
+func (dfs *dfSearcher) HasNext() bool {
+	return dfs.next != nil
+}
+
+// moves search forward till the next vertex to return, returns `nil` when search is over
+func (dfs *dfSearcher) advance() *Vertex {
+	for len(dfs.stack) > 0 {
+		top := &dfs.stack[len(dfs.stack)-1]
+		if top.index < len(top.ends) {
+			end := top.ends[top.index]
+			top.index++
+			// already visited vertexes are skipped, so cycles don't lead to infinite descending
+			if dfs.visited.Contains(end.vertex) {
+				continue
+			}
+			dfs.reachedBy[end.vertex] = end.edge
+			dfs.discover(end.vertex, top.depth+1)
+			if dfs.preOrder {
+				return end.vertex
+			}
+			continue
 		}
-		return vtx
+
+		dfs.stack = dfs.stack[:len(dfs.stack)-1]
+		dfs.clock++
+		visitTime := dfs.times[top.vtx]
+		visitTime.Finished = dfs.clock
+		dfs.times[top.vtx] = visitTime
+		if !dfs.preOrder {
+			return top.vtx
+		}
+	}
+	return nil
+}
+
+func (dfs *dfSearcher) discover(vtx *Vertex, depth int) {
+	dfs.visited.put(vtx)
+	dfs.clock++
+	dfs.times[vtx] = VisitTime{Discovered: dfs.clock}
+	dfs.stack = append(dfs.stack, dfFrame{vtx: vtx, ends: dfs.ends(vtx, depth), depth: depth})
+}
+
+// Depth-First Search that returns vertexes in reverse post-order
+type reversePostOrderSearcher struct {
+	vtxs []*Vertex
+	reachedBy
+}
+
+func newReversePostOrderSearcher(vtx *Vertex, t traversal) *reversePostOrderSearcher {
+	dfs := newDFSearcher(vtx, t, false)
+	var vtxs []*Vertex
+	for dfs.HasNext() {
+		vtxs = append(vtxs, dfs.Next())
 	}
+	return &reversePostOrderSearcher{vtxs: vtxs, reachedBy: dfs.reachedBy}
 }
 
-func (dfs *dfSearcherWrapper) HasNext() bool {
-	return dfs.searcher != nil
+func (rpos *reversePostOrderSearcher) Next() *Vertex {
+	vtx := rpos.vtxs[len(rpos.vtxs)-1]
+	rpos.vtxs = rpos.vtxs[:len(rpos.vtxs)-1]
+	return vtx
+}
+
+func (rpos *reversePostOrderSearcher) HasNext() bool {
+	return len(rpos.vtxs) > 0
 }
 
 // Breadth-First Search implementation structs
 type bfSearcher struct {
+	traversal
 	check []*Vertex
-	index int
+	// depth of each vertex in `check`
+	depths  []int
+	index   int
+	visited VertexSet
+	reachedBy
 }
 
 func (bfs *bfSearcher) Next() *Vertex {
-	vtx := bfs.check[bfs.index]
+	vtx, depth := bfs.check[bfs.index], bfs.depths[bfs.index]
 	bfs.index++
-	bfs.check = append(bfs.check, vtx.Outcoming().Vertexes()...)
+	// vertex is queued only once, so cycles don't lead to infinite growth of the queue
+	for _, end := range bfs.ends(vtx, depth) {
+		if !bfs.visited.Contains(end.vertex) {
+			bfs.visited.put(end.vertex)
+			bfs.reachedBy[end.vertex] = end.edge
+			bfs.check = append(bfs.check, end.vertex)
+			bfs.depths = append(bfs.depths, depth+1)
+		}
+	}
 	return vtx
 }
 
@@ -73,3 +176,7 @@ func (badSearcher) Next() *Vertex {
 func (badSearcher) HasNext() bool {
 	return false
 }
+
+func (badSearcher) path(*Vertex) Path {
+	return Path{}
+}
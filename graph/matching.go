@@ -0,0 +1,286 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// Error returned when vertexes can't be split into two parts because there is a cycle of odd length between them
+type OddCycleError struct {
+	// cycle that starts and ends at the same vertex
+	Cycle Path
+}
+
+func (oce *OddCycleError) Error() string {
+	return fmt.Sprintf("graph: not a bipartite graph, found cycle of %d edges", oce.Cycle.Len())
+}
+
+// Splits vertexes of the set into two parts so that each edge between vertexes of the set connects vertexes
+// of different parts. Direction of edges is ignored. The first vertex of each connected component goes to the left part.
+// Returns `*OddCycleError` if it is not possible.
+func Bipartition(vs VertexSet) (left, right VertexSet, err error) {
+	left, right = NewVertexSet(), NewVertexSet()
+	reached := reachedBy{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		root := iterator.Next()
+		if left.Contains(root) || right.Contains(root) {
+			continue
+		}
+		left.put(root)
+		for check := []*Vertex{root}; len(check) > 0; check = check[1:] {
+			vtx := check[0]
+			same, opposite := left, right
+			if right.Contains(vtx) {
+				same, opposite = right, left
+			}
+			for _, end := range vtx.incidentEnds() {
+				switch {
+				case !vs.Contains(end.vertex) || opposite.Contains(end.vertex):
+				case same.Contains(end.vertex):
+					return VertexSet{}, VertexSet{}, &OddCycleError{Cycle: oddCycle(vtx, end, reached)}
+				default:
+					opposite.put(end.vertex)
+					reached[end.vertex] = end.edge
+					check = append(check, end.vertex)
+				}
+			}
+		}
+	}
+	return left, right, nil
+}
+
+// builds cycle from two branches of breadth-first search tree joined by the edge
+func oddCycle(vtx *Vertex, end edgeEnd, reached reachedBy) Path {
+	one, another := pathTo(vtx, reached, 0), pathTo(end.vertex, reached, 0)
+	// both paths start at the root, the cycle starts at the last vertex they share
+	common := 0
+	for common+1 < len(one.vertexes) && common+1 < len(another.vertexes) && one.vertexes[common+1] == another.vertexes[common+1] {
+		common++
+	}
+	cycle := Path{
+		vertexes: append([]*Vertex{}, one.vertexes[common:]...),
+		edges:    append(append([]*Edge{}, one.edges[common:]...), end.edge),
+	}
+	for i := len(another.vertexes) - 1; i >= common; i-- {
+		cycle.vertexes = append(cycle.vertexes, another.vertexes[i])
+	}
+	for i := len(another.edges) - 1; i >= common; i-- {
+		cycle.edges = append(cycle.edges, another.edges[i])
+	}
+	cycle.cost = float64(cycle.Len())
+	return cycle
+}
+
+// Returns the biggest set of edges between vertexes of the set where no two edges share a vertex,
+// found with Hopcroft-Karp algorithm. Vertexes must form a bipartite graph, see `Bipartition`.
+func MaximumMatching(vs VertexSet) (EdgeSet, error) {
+	bg, err := newBipartiteGraph(vs)
+	if err != nil {
+		return EdgeSet{}, err
+	}
+
+	const free = -1
+	leftMatch := make([]int, len(bg.left))
+	rightMatch := make([]int, len(bg.right))
+	for index := range leftMatch {
+		leftMatch[index] = free
+	}
+	for index := range rightMatch {
+		rightMatch[index] = free
+	}
+
+	distances := make([]int, len(bg.left))
+	// layers alternating paths from free left vertexes, reports if any free right vertex was reached
+	layer := func() (found bool) {
+		var check []int
+		for index := range bg.left {
+			if leftMatch[index] == free {
+				distances[index] = 0
+				check = append(check, index)
+			} else {
+				distances[index] = math.MaxInt32
+			}
+		}
+		for ; len(check) > 0; check = check[1:] {
+			for _, right := range bg.adjacent[check[0]] {
+				switch next := rightMatch[right]; {
+				case next == free:
+					found = true
+				case distances[next] == math.MaxInt32:
+					distances[next] = distances[check[0]] + 1
+					check = append(check, next)
+				}
+			}
+		}
+		return
+	}
+	var augment func(left int) bool
+	augment = func(left int) bool {
+		for _, right := range bg.adjacent[left] {
+			next := rightMatch[right]
+			if next == free || (distances[next] == distances[left]+1 && augment(next)) {
+				leftMatch[left], rightMatch[right] = right, left
+				return true
+			}
+		}
+		distances[left] = math.MaxInt32
+		return false
+	}
+	for layer() {
+		for index := range bg.left {
+			if leftMatch[index] == free {
+				augment(index)
+			}
+		}
+	}
+
+	matching := NewEdgeSet()
+	for left, right := range leftMatch {
+		if right != free {
+			edge := bg.edges[[2]int{left, right}]
+			matching.put(edgeEnd{edge: edge, vertex: edge.to})
+		}
+	}
+	return matching, nil
+}
+
+// Returns set of edges between vertexes of the set where no two edges share a vertex and total weight
+// of edges is the biggest, found with Hungarian algorithm. Edges with not positive weight are never matched.
+// Vertexes must form a bipartite graph, see `Bipartition`.
+func MaximumWeightMatching(vs VertexSet, weight EdgeWeight) (EdgeSet, error) {
+	bg, err := newBipartiteGraph(vs)
+	if err != nil {
+		return EdgeSet{}, err
+	}
+
+	// square matrix of costs where missing edges have zero weight, so assigning them means leaving vertex unmatched
+	size := len(bg.left)
+	if len(bg.right) > size {
+		size = len(bg.right)
+	}
+	weights := make([][]float64, size)
+	chosen := map[[2]int]*Edge{}
+	for index := range weights {
+		weights[index] = make([]float64, size)
+	}
+	for left, rights := range bg.adjacent {
+		for _, right := range rights {
+			key := [2]int{left, right}
+			for _, edge := range bg.parallel[key] {
+				if edgeWeight := weight(edge); edgeWeight > weights[left][right] {
+					weights[left][right] = edgeWeight
+					chosen[key] = edge
+				}
+			}
+		}
+	}
+
+	matching := NewEdgeSet()
+	for left, right := range hungarian(weights) {
+		if edge, found := chosen[[2]int{left, right}]; found {
+			matching.put(edgeEnd{edge: edge, vertex: edge.to})
+		}
+	}
+	return matching, nil
+}
+
+// returns column assigned to each row so that total weight is maximal, matrix must be square
+func hungarian(weights [][]float64) []int {
+	size := len(weights)
+	// potentials of rows and columns and row assigned to each column, all of them are 1-based with 0 as a fake row
+	rowPotentials := make([]float64, size+1)
+	columnPotentials := make([]float64, size+1)
+	assigned := make([]int, size+1)
+	previous := make([]int, size+1)
+	for row := 1; row <= size; row++ {
+		assigned[0] = row
+		column := 0
+		minimums := make([]float64, size+1)
+		used := make([]bool, size+1)
+		for index := range minimums {
+			minimums[index] = math.Inf(1)
+		}
+		for assigned[column] != 0 {
+			used[column] = true
+			currentRow, delta, nextColumn := assigned[column], math.Inf(1), 0
+			for j := 1; j <= size; j++ {
+				if used[j] {
+					continue
+				}
+				// weights are negated, because the algorithm minimizes total cost
+				cost := -weights[currentRow-1][j-1] - rowPotentials[currentRow] - columnPotentials[j]
+				if cost < minimums[j] {
+					minimums[j] = cost
+					previous[j] = column
+				}
+				if minimums[j] < delta {
+					delta = minimums[j]
+					nextColumn = j
+				}
+			}
+			for j := 0; j <= size; j++ {
+				if used[j] {
+					rowPotentials[assigned[j]] += delta
+					columnPotentials[j] -= delta
+				} else {
+					minimums[j] -= delta
+				}
+			}
+			column = nextColumn
+		}
+		for column != 0 {
+			assigned[column] = assigned[previous[column]]
+			column = previous[column]
+		}
+	}
+
+	columns := make([]int, size)
+	for column := 1; column <= size; column++ {
+		columns[assigned[column]-1] = column - 1
+	}
+	return columns
+}
+
+// Bipartite graph with vertexes replaced by their positions in parts
+type bipartiteGraph struct {
+	left, right []*Vertex
+	// positions of distinct right vertexes adjacent to each left vertex
+	adjacent [][]int
+	// the first edge between left and right vertexes
+	edges map[[2]int]*Edge
+	// all edges between left and right vertexes
+	parallel map[[2]int][]*Edge
+}
+
+func newBipartiteGraph(vs VertexSet) (*bipartiteGraph, error) {
+	left, right, err := Bipartition(vs)
+	if err != nil {
+		return nil, err
+	}
+	bg := &bipartiteGraph{edges: map[[2]int]*Edge{}, parallel: map[[2]int][]*Edge{}}
+	rightIndexes := map[*Vertex]int{}
+	for iterator := right.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		rightIndexes[vtx] = len(bg.right)
+		bg.right = append(bg.right, vtx)
+	}
+	for iterator := left.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		index := len(bg.left)
+		bg.left = append(bg.left, vtx)
+		bg.adjacent = append(bg.adjacent, nil)
+		for _, end := range vtx.incidentEnds() {
+			rightIndex, found := rightIndexes[end.vertex]
+			if !found {
+				continue
+			}
+			key := [2]int{index, rightIndex}
+			if _, found := bg.edges[key]; !found {
+				bg.edges[key] = end.edge
+				bg.adjacent[index] = append(bg.adjacent[index], rightIndex)
+			}
+			bg.parallel[key] = append(bg.parallel[key], end.edge)
+		}
+	}
+	return bg, nil
+}
@@ -1,44 +1,57 @@
 package graph
 
 // returns first N found vertexes
-func FindN(algorithm SearchAlgorithm, number uint, isFound VertexPredicate) TraversingStrategy {
-	return &findNFirstSearch{algorithm: algorithm, number: number, isFound: isFound}
+func FindN(algorithm SearchAlgorithm, number uint, isFound VertexPredicate, options ...TraversalOption) PathTraversingStrategy {
+	return &findNFirstSearch{algorithm: algorithm, number: number, isFound: isFound, traversal: newTraversal(options)}
 }
 
 // returns first found vertex
-func FindFirst(algorithm SearchAlgorithm, isFound VertexPredicate) TraversingStrategy {
-	return FindN(algorithm, 1, isFound)
+func FindFirst(algorithm SearchAlgorithm, isFound VertexPredicate, options ...TraversalOption) PathTraversingStrategy {
+	return FindN(algorithm, 1, isFound, options...)
 }
 
 type findNFirstSearch struct {
 	algorithm SearchAlgorithm
 	number    uint
 	isFound   VertexPredicate
+	traversal traversal
 }
 
-func (nfs *findNFirstSearch) Search(vtx *Vertex) (found []*Vertex) {
-	for iterator := nfs.algorithm.StartAt(vtx); nfs.number != 0 && iterator.HasNext(); {
+func (nfs *findNFirstSearch) Search(vtx *Vertex) []*Vertex {
+	return pathEnds(nfs.SearchPaths(vtx))
+}
+
+func (nfs *findNFirstSearch) SearchPaths(vtx *Vertex) (found []Path) {
+	iterator := nfs.algorithm.startAt(vtx, nfs.traversal)
+	for number := nfs.number; number != 0 && iterator.HasNext(); {
 		nVtx := iterator.Next()
 		if nfs.isFound(nVtx) {
-			found = append(found, nVtx)
-			nfs.number--
+			found = append(found, iterator.path(nVtx))
+			number--
 		}
 	}
 	return
 }
 
 // returns all vertexes that suits to predicate
-func FindAll(isFound VertexPredicate) TraversingStrategy {
-	return findAll(isFound)
+func FindAll(isFound VertexPredicate, options ...TraversalOption) PathTraversingStrategy {
+	return &findAll{isFound: isFound, traversal: newTraversal(options)}
+}
+
+type findAll struct {
+	isFound   VertexPredicate
+	traversal traversal
 }
 
-type findAll VertexPredicate
+func (fa *findAll) Search(vtx *Vertex) []*Vertex {
+	return pathEnds(fa.SearchPaths(vtx))
+}
 
-func (isFound findAll) Search(vtx *Vertex) (found []*Vertex) {
-	for iterator := BFS.StartAt(vtx); iterator.HasNext(); {
+func (fa *findAll) SearchPaths(vtx *Vertex) (found []Path) {
+	for iterator := BFS.startAt(vtx, fa.traversal); iterator.HasNext(); {
 		nVtx := iterator.Next()
-		if isFound(nVtx) {
-			found = append(found, nVtx)
+		if fa.isFound(nVtx) {
+			found = append(found, iterator.path(nVtx))
 		}
 	}
 	return
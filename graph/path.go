@@ -0,0 +1,75 @@
+package graph
+
+// Route over the graph: ordered vertexes and edges that connect them
+type Path struct {
+	vertexes []*Vertex
+	edges    []*Edge
+	cost     float64
+}
+
+// Vertexes of the path starting from the first one
+func (p Path) Vertexes() []*Vertex {
+	return p.vertexes
+}
+
+// Edges of the path, edge with index `i` connects vertexes with indexes `i` and `i+1`
+func (p Path) Edges() []*Edge {
+	return p.edges
+}
+
+// Vertex the path starts at
+func (p Path) Start() *Vertex {
+	if len(p.vertexes) == 0 {
+		return nil
+	}
+	return p.vertexes[0]
+}
+
+// Vertex the path ends at
+func (p Path) End() *Vertex {
+	if len(p.vertexes) == 0 {
+		return nil
+	}
+	return p.vertexes[len(p.vertexes)-1]
+}
+
+// Amount of edges in the path
+func (p Path) Len() int {
+	return len(p.edges)
+}
+
+// Total weight of all edges of the path
+func (p Path) Cost() float64 {
+	return p.cost
+}
+
+// builds path that ends at `to` by going back over `previous` edges
+func pathTo(to *Vertex, previous map[*Vertex]*Edge, cost float64) Path {
+	path := Path{vertexes: []*Vertex{to}, cost: cost}
+	for vtx := to; previous[vtx] != nil; {
+		edge := previous[vtx]
+		vtx = edge.Other(vtx)
+		path.vertexes = append(path.vertexes, vtx)
+		path.edges = append(path.edges, edge)
+	}
+	reverseVertexes(path.vertexes)
+	for i, j := 0, len(path.edges)-1; i < j; i, j = i+1, j-1 {
+		path.edges[i], path.edges[j] = path.edges[j], path.edges[i]
+	}
+	return path
+}
+
+// returns vertexes paths end at
+func pathEnds(paths []Path) []*Vertex {
+	var vtxs []*Vertex
+	for _, path := range paths {
+		vtxs = append(vtxs, path.End())
+	}
+	return vtxs
+}
+
+func reverseVertexes(vtxs []*Vertex) {
+	for i, j := 0, len(vtxs)-1; i < j; i, j = i+1, j-1 {
+		vtxs[i], vtxs[j] = vtxs[j], vtxs[i]
+	}
+}
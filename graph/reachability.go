@@ -0,0 +1,71 @@
+package graph
+
+// Vertexes reachable from the vertex over outcoming edges that suit to the predicate,
+// `nil` predicate means all edges. The vertex itself is not included even if it is on a cycle.
+func (vtx *Vertex) Descendants(predicate EdgePredicate) VertexSet {
+	return vtx.reachableIn(OutcomingDirection, predicate)
+}
+
+// Vertexes the vertex is reachable from over edges that suit to the predicate,
+// `nil` predicate means all edges. The vertex itself is not included even if it is on a cycle.
+func (vtx *Vertex) Ancestors(predicate EdgePredicate) VertexSet {
+	return vtx.reachableIn(IncomingDirection, predicate)
+}
+
+func (vtx *Vertex) reachableIn(direction Direction, predicate EdgePredicate) VertexSet {
+	vs := NewVertexSet()
+	iterator := BFS.StartAt(vtx, OverEdges(predicate), InDirection(direction))
+	// the first returned vertex is the start one
+	for iterator.Next(); iterator.HasNext(); {
+		vs.put(iterator.Next())
+	}
+	return vs
+}
+
+// Reports if `to` vertex can be reached from `from` vertex over outcoming edges that suit to the predicate,
+// `nil` predicate means all edges. Search goes from both vertexes at once, so usually visits less vertexes.
+func Reachable(from, to *Vertex, predicate EdgePredicate) bool {
+	if from == to {
+		return true
+	}
+	forward := &reachFrontier{vtxs: []*Vertex{from}, seen: NewVertexSet(from), direction: OutcomingDirection}
+	backward := &reachFrontier{vtxs: []*Vertex{to}, seen: NewVertexSet(to), direction: IncomingDirection}
+	t := newTraversal([]TraversalOption{OverEdges(predicate)})
+	for len(forward.vtxs) > 0 && len(backward.vtxs) > 0 {
+		// the smaller frontier is expanded, so search doesn't blow up on one side
+		expanding, other := forward, backward
+		if len(backward.vtxs) < len(forward.vtxs) {
+			expanding, other = backward, forward
+		}
+		if expanding.expand(t, other.seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Vertexes reached on the last step of the search in one direction
+type reachFrontier struct {
+	vtxs      []*Vertex
+	seen      VertexSet
+	direction Direction
+}
+
+// makes one more step of the search, reports if any of vertexes seen by the search in the opposite direction was met
+func (rf *reachFrontier) expand(t traversal, met VertexSet) bool {
+	t.direction = rf.direction
+	var next []*Vertex
+	for _, vtx := range rf.vtxs {
+		for _, end := range t.ends(vtx, 0) {
+			if met.Contains(end.vertex) {
+				return true
+			}
+			if !rf.seen.Contains(end.vertex) {
+				rf.seen.put(end.vertex)
+				next = append(next, end.vertex)
+			}
+		}
+	}
+	rf.vtxs = next
+	return false
+}
@@ -0,0 +1,152 @@
+package graph
+
+// Returns key that identifies vertex by its data, keys must be comparable
+type DataKey func(data interface{}) interface{}
+
+// Returns graph with vertexes and edges of both graphs.
+// Vertexes are the same if keys of their data are equal, `nil` key compares data itself, data of the first vertex is taken.
+// Edges are the same if they connect the same vertexes in the same direction, parallel edges are counted,
+// so the result has as many of them as the graph that has more. Attributes of edges are shared with the graphs.
+func Union(one, another *Graph, key DataKey) *Graph {
+	return combine(one, another, key, func(inOne, inAnother bool) bool {
+		return true
+	}, func(inOne, inAnother int) (int, int) {
+		return inOne, positive(inAnother - inOne)
+	})
+}
+
+// Returns graph with vertexes and edges that are in both graphs, vertexes and edges are compared as for `Union`
+func Intersection(one, another *Graph, key DataKey) *Graph {
+	return combine(one, another, key, func(inOne, inAnother bool) bool {
+		return inOne && inAnother
+	}, func(inOne, inAnother int) (int, int) {
+		if inOne < inAnother {
+			return inOne, 0
+		}
+		return inAnother, 0
+	})
+}
+
+// Returns graph with all vertexes of the first graph and its edges that are not in another graph,
+// vertexes and edges are compared as for `Union`
+func Difference(one, another *Graph, key DataKey) *Graph {
+	return combine(one, another, key, func(inOne, inAnother bool) bool {
+		return inOne
+	}, func(inOne, inAnother int) (int, int) {
+		return positive(inOne - inAnother), 0
+	})
+}
+
+// Returns graph with vertexes of both graphs and edges that are only in one of them,
+// vertexes and edges are compared as for `Union`
+func SymmetricDifference(one, another *Graph, key DataKey) *Graph {
+	return combine(one, another, key, func(inOne, inAnother bool) bool {
+		return true
+	}, func(inOne, inAnother int) (int, int) {
+		return positive(inOne - inAnother), positive(inAnother - inOne)
+	})
+}
+
+// Returns graph with copies of vertexes of the graph connected where the graph has no edges between them.
+// Directed complement has an edge to each vertex the vertex has no outcoming edge to,
+// otherwise vertexes that are not adjacent are connected with undirected edges. Self-loops are not added.
+func Complement(g *Graph, directed bool) *Graph {
+	vertexes := g.Vertexes()
+	complement := NewGraph()
+	copies := make([]*Vertex, 0, vertexes.Len())
+	for iterator := vertexes.Iterator(); iterator.HasNext(); {
+		copies = append(copies, complement.AddVertex(iterator.Next().data))
+	}
+	for i, iterator := 0, vertexes.Iterator(); iterator.HasNext(); i++ {
+		vtx := iterator.Next()
+		linked := vtx.Adjacent()
+		if directed {
+			linked = NewVertexSet()
+			for _, end := range vtx.outcomingEnds() {
+				linked.put(end.vertex)
+			}
+		}
+		for j, other := 0, vertexes.Iterator(); other.HasNext(); j++ {
+			otherVtx := other.Next()
+			switch {
+			case i == j || linked.Contains(otherVtx):
+			case directed:
+				copies[i].EdgeTo(copies[j])
+			case i < j:
+				copies[i].Edge(copies[j])
+			}
+		}
+	}
+	return complement
+}
+
+// Edge of combined graphs identified by positions of vertexes it connects, undirected edge has the smaller position first
+type edgeIdentity struct {
+	from, to   int
+	undirected bool
+}
+
+// builds graph of vertexes of two graphs selected by presence in them
+// and edges taken from each graph by amount of edges with the same identity in both graphs
+func combine(one, another *Graph, key DataKey, takeVertex func(inOne, inAnother bool) bool, takeEdges func(inOne, inAnother int) (int, int)) *Graph {
+	if key == nil {
+		key = func(data interface{}) interface{} { return data }
+	}
+	positions := map[interface{}]int{}
+	var vertexData []interface{}
+	var inOne, inAnother []bool
+	for _, g := range []*Graph{one, another} {
+		for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+			data := iterator.Next().data
+			position, found := positions[key(data)]
+			if !found {
+				position = len(vertexData)
+				positions[key(data)] = position
+				vertexData = append(vertexData, data)
+				inOne, inAnother = append(inOne, false), append(inAnother, false)
+			}
+			inOne[position] = inOne[position] || g == one
+			inAnother[position] = inAnother[position] || g == another
+		}
+	}
+
+	var identities []edgeIdentity
+	edges := [2]map[edgeIdentity][]*Edge{{}, {}}
+	for i, g := range []*Graph{one, another} {
+		for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+			edge := iterator.Next()
+			identity := edgeIdentity{from: positions[key(edge.from.data)], to: positions[key(edge.to.data)], undirected: edge.undirected}
+			if identity.undirected && identity.from > identity.to {
+				identity.from, identity.to = identity.to, identity.from
+			}
+			if len(edges[0][identity]) == 0 && len(edges[1][identity]) == 0 {
+				identities = append(identities, identity)
+			}
+			edges[i][identity] = append(edges[i][identity], edge)
+		}
+	}
+
+	combined := NewGraph()
+	vertexes := make([]*Vertex, len(vertexData))
+	for position, data := range vertexData {
+		if takeVertex(inOne[position], inAnother[position]) {
+			vertexes[position] = combined.AddVertex(data)
+		}
+	}
+	for _, identity := range identities {
+		fromOne, fromAnother := takeEdges(len(edges[0][identity]), len(edges[1][identity]))
+		taken := append(edges[0][identity][:fromOne:fromOne], edges[1][identity][len(edges[1][identity])-fromAnother:]...)
+		for _, edge := range taken {
+			from, to := vertexes[positions[key(edge.from.data)]], vertexes[positions[key(edge.to.data)]]
+			link(&Edge{from: from, to: to, attributes: edge.Attributes(), undirected: edge.undirected})
+		}
+	}
+	return combined
+}
+
+func positive(value int) int {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,202 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+)
+
+var (
+	// target vertex can't be reached from the start vertex
+	ErrUnreachable = errors.New("graph: vertex is unreachable")
+	// edge with negative weight was met by algorithm that doesn't support it
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// there is a cycle with negative total weight, so the cheapest path doesn't exist
+	ErrNegativeCycle = errors.New("graph: negative cycle")
+)
+
+// Extracts weight of the edge, usually from its attributes
+type EdgeWeight func(edge *Edge) float64
+
+// Estimates cost of the cheapest path from the vertex to the target one
+type Heuristic func(vtx *Vertex) float64
+
+// Weight of any edge is 1, so cost of the path is amount of edges in it
+func UnitWeight(*Edge) float64 {
+	return 1
+}
+
+// returns the cheapest path to `to` vertex, weights of edges must not be negative
+func Dijkstra(to *Vertex, weight EdgeWeight) ShortestPathStrategy {
+	return AStar(to, weight, func(*Vertex) float64 { return 0 })
+}
+
+// returns the cheapest path to `to` vertex exploring vertexes with the best estimate first,
+// weights of edges must not be negative and `heuristic` must not overestimate the cost
+func AStar(to *Vertex, weight EdgeWeight, heuristic Heuristic) ShortestPathStrategy {
+	return &aStarSearch{to: to, weight: weight, heuristic: heuristic}
+}
+
+type aStarSearch struct {
+	to        *Vertex
+	weight    EdgeWeight
+	heuristic Heuristic
+}
+
+func (as *aStarSearch) Search(vtx *Vertex) []*Vertex {
+	return searchShortest(as, vtx)
+}
+
+func (as *aStarSearch) SearchPaths(vtx *Vertex) []Path {
+	return searchShortestPaths(as, vtx)
+}
+
+func (as *aStarSearch) ShortestPath(from *Vertex) (Path, error) {
+	costs := map[*Vertex]float64{from: 0}
+	previous := map[*Vertex]*Edge{}
+	queue := &costQueue{}
+	heap.Push(queue, costQueueItem{vtx: from, priority: as.heuristic(from)})
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(costQueueItem)
+		if item.vtx == as.to {
+			return pathTo(as.to, previous, item.cost), nil
+		}
+		if item.cost > costs[item.vtx] {
+			// vertex was already reached cheaper after the item was queued
+			continue
+		}
+		for _, end := range item.vtx.outcomingEnds() {
+			weight := as.weight(end.edge)
+			if weight < 0 {
+				return Path{}, ErrNegativeWeight
+			}
+			cost := item.cost + weight
+			if known, found := costs[end.vertex]; found && known <= cost {
+				continue
+			}
+			costs[end.vertex] = cost
+			previous[end.vertex] = end.edge
+			heap.Push(queue, costQueueItem{vtx: end.vertex, cost: cost, priority: cost + as.heuristic(end.vertex)})
+		}
+	}
+	return Path{}, ErrUnreachable
+}
+
+// returns the cheapest path to `to` vertex, weights of edges can be negative
+func BellmanFord(to *Vertex, weight EdgeWeight) ShortestPathStrategy {
+	return &bellmanFordSearch{to: to, weight: weight}
+}
+
+type bellmanFordSearch struct {
+	to     *Vertex
+	weight EdgeWeight
+}
+
+func (bfs *bellmanFordSearch) Search(vtx *Vertex) []*Vertex {
+	return searchShortest(bfs, vtx)
+}
+
+func (bfs *bellmanFordSearch) SearchPaths(vtx *Vertex) []Path {
+	return searchShortestPaths(bfs, vtx)
+}
+
+func (bfs *bellmanFordSearch) ShortestPath(from *Vertex) (Path, error) {
+	var vtxs []*Vertex
+	for iterator := BFS.StartAt(from); iterator.HasNext(); {
+		vtxs = append(vtxs, iterator.Next())
+	}
+
+	costs := map[*Vertex]float64{from: 0}
+	previous := map[*Vertex]*Edge{}
+	relax := func() (relaxed bool) {
+		for _, vtx := range vtxs {
+			vtxCost, found := costs[vtx]
+			if !found {
+				continue
+			}
+			for _, end := range vtx.outcomingEnds() {
+				cost := vtxCost + bfs.weight(end.edge)
+				if known, found := costs[end.vertex]; found && known <= cost {
+					continue
+				}
+				costs[end.vertex] = cost
+				previous[end.vertex] = end.edge
+				relaxed = true
+			}
+		}
+		return
+	}
+	for i := 1; i < len(vtxs); i++ {
+		if !relax() {
+			break
+		}
+	}
+	if relax() {
+		return Path{}, ErrNegativeCycle
+	}
+
+	cost, found := costs[bfs.to]
+	if !found {
+		return Path{}, ErrUnreachable
+	}
+	return pathTo(bfs.to, previous, cost), nil
+}
+
+func searchShortest(strategy ShortestPathStrategy, vtx *Vertex) []*Vertex {
+	path, err := strategy.ShortestPath(vtx)
+	if err != nil {
+		return nil
+	}
+	return path.Vertexes()
+}
+
+func searchShortestPaths(strategy ShortestPathStrategy, vtx *Vertex) []Path {
+	path, err := strategy.ShortestPath(vtx)
+	if err != nil {
+		return nil
+	}
+	return []Path{path}
+}
+
+type costQueueItem struct {
+	vtx *Vertex
+	// edge the vertex was reached by
+	edge     *Edge
+	cost     float64
+	priority float64
+	order    int
+}
+
+// Priority queue of vertexes, items with the same priority are popped in order they were pushed
+type costQueue struct {
+	items  []costQueueItem
+	pushed int
+}
+
+func (cq *costQueue) Len() int {
+	return len(cq.items)
+}
+
+func (cq *costQueue) Less(i, j int) bool {
+	if cq.items[i].priority == cq.items[j].priority {
+		return cq.items[i].order < cq.items[j].order
+	}
+	return cq.items[i].priority < cq.items[j].priority
+}
+
+func (cq *costQueue) Swap(i, j int) {
+	cq.items[i], cq.items[j] = cq.items[j], cq.items[i]
+}
+
+func (cq *costQueue) Push(item interface{}) {
+	cqi := item.(costQueueItem)
+	cqi.order = cq.pushed
+	cq.pushed++
+	cq.items = append(cq.items, cqi)
+}
+
+func (cq *costQueue) Pop() interface{} {
+	last := len(cq.items) - 1
+	item := cq.items[last]
+	cq.items = cq.items[:last]
+	return item
+}
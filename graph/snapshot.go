@@ -0,0 +1,296 @@
+package graph
+
+import "sync"
+
+// Read-only view of a graph at the moment it was taken, later changes of the graph are not visible in it.
+// Taking a snapshot doesn't copy anything: the graph keeps previous versions of vertexes, edges and attributes
+// only when they are changed while a snapshot needs them. Vertexes and edges of the view are separate values
+// created on first access, so paths and iterators started at them never leave the view.
+// A snapshot can be read from several goroutines at once, any change of it panics.
+// Snapshot needs a graph created with `NewConcurrentGraph` to be read while other goroutines change the graph:
+// views are filled from the graph under its lock, and a graph created with `NewGraph` has no lock.
+type Snapshot struct {
+	*Graph
+	source *Graph
+	epoch  int
+
+	once     sync.Once
+	mu       sync.Mutex
+	released bool
+	vertexes map[*Vertex]*Vertex
+	edges    map[*Edge]*Edge
+}
+
+// Takes snapshot of the graph, snapshot of a snapshot is the snapshot itself.
+// Snapshot of a graph created with `NewGraph` can't be read while the graph is changed.
+func (g *Graph) Snapshot() *Snapshot {
+	if g.snapshot != nil {
+		return g.snapshot
+	}
+	lock(g.guard)
+	defer unlock(g.guard)
+	s := &Snapshot{
+		source:   g,
+		epoch:    g.epoch,
+		vertexes: map[*Vertex]*Vertex{},
+		edges:    map[*Edge]*Edge{},
+	}
+	s.Graph = &Graph{snapshot: s}
+	g.epoch++
+	g.snapshots = append(g.snapshots, s.epoch)
+	return s
+}
+
+// Returns vertex of the snapshot that corresponds to provided vertex of the graph
+// or `nil` if the vertex wasn't in the graph when the snapshot was taken
+func (s *Snapshot) Vertex(vtx *Vertex) *Vertex {
+	rlock(s.source.guard)
+	defer runlock(s.source.guard)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		// released view is filled completely, so it has all vertexes of the snapshot
+		return s.vertexes[vtx]
+	}
+	if vertexes, _ := s.source.stateAt(s.epoch); !vertexes.Contains(vtx) {
+		return nil
+	}
+	return s.viewVertex(vtx)
+}
+
+// Lets the graph drop versions kept for the snapshot.
+// Parts of the view that were not read yet are filled before that, so the snapshot stays readable after it.
+func (s *Snapshot) Release() {
+	s.materialize()
+	// all vertexes of the view exist after the graph of the view is filled
+	for iterator := s.Graph.vertexes.Iterator(); iterator.HasNext(); {
+		iterator.Next().materialize()
+	}
+	lock(s.source.guard)
+	defer unlock(s.source.guard)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		return
+	}
+	s.released = true
+	for i, epoch := range s.source.snapshots {
+		if epoch == s.epoch {
+			s.source.snapshots = append(s.source.snapshots[:i:i], s.source.snapshots[i+1:]...)
+			break
+		}
+	}
+}
+
+// fills vertexes and edges of the view graph
+func (s *Snapshot) materialize() {
+	s.once.Do(func() {
+		rlock(s.source.guard)
+		defer runlock(s.source.guard)
+		vertexes, edges := s.source.stateAt(s.epoch)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.Graph.vertexes = NewVertexSet()
+		for iterator := vertexes.Iterator(); iterator.HasNext(); {
+			s.Graph.vertexes.put(s.viewVertex(iterator.Next()))
+		}
+		s.Graph.edges = NewEdgeSet()
+		for _, end := range edges.ends() {
+			s.Graph.edges.put(s.viewEnd(end))
+		}
+	})
+}
+
+// returns vertex of the view for a vertex of the graph, edges of the vertex are filled on first access
+func (s *Snapshot) viewVertex(vtx *Vertex) *Vertex {
+	view, found := s.vertexes[vtx]
+	if !found {
+		view = &Vertex{data: vtx.data, graph: s.Graph, view: &vertexView{snapshot: s, source: vtx}}
+		s.vertexes[vtx] = view
+	}
+	return view
+}
+
+func (s *Snapshot) viewEnd(end edgeEnd) edgeEnd {
+	edge, found := s.edges[end.edge]
+	if !found {
+		edge = &Edge{
+			from:       s.viewVertex(end.edge.from),
+			to:         s.viewVertex(end.edge.to),
+			attributes: end.edge.attributesAt(s.epoch),
+			undirected: end.edge.undirected,
+		}
+		s.edges[end.edge] = edge
+	}
+	return edgeEnd{edge: edge, vertex: s.viewVertex(end.vertex)}
+}
+
+// Vertex of a snapshot together with the vertex of the graph it was taken from
+type vertexView struct {
+	snapshot *Snapshot
+	source   *Vertex
+	once     sync.Once
+}
+
+// fills edges of the vertex if it belongs to a snapshot
+func (vtx *Vertex) materialize() {
+	if vtx.view == nil {
+		return
+	}
+	vtx.view.once.Do(func() {
+		s, source := vtx.view.snapshot, vtx.view.source
+		rlock(source.guard)
+		defer runlock(source.guard)
+		state := source.stateAt(s.epoch)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		vtx.outcoming, vtx.incoming, vtx.adjacent = NewEdgeSet(), NewEdgeSet(), NewVertexSet()
+		for _, end := range state.outcoming.ends() {
+			vtx.outcoming.put(s.viewEnd(end))
+		}
+		for _, end := range state.incoming.ends() {
+			vtx.incoming.put(s.viewEnd(end))
+		}
+		for iterator := state.adjacent.Iterator(); iterator.HasNext(); {
+			vtx.adjacent.put(s.viewVertex(iterator.Next()))
+		}
+	})
+}
+
+// panics if the vertex belongs to a snapshot
+func (vtx *Vertex) mutable() {
+	if vtx.view != nil {
+		panic("graph: snapshot is read-only")
+	}
+}
+
+// Edges of a vertex that were replaced after a snapshot had been taken
+type vertexVersion struct {
+	since, until        int
+	incoming, outcoming EdgeSet
+	adjacent            VertexSet
+}
+
+// Vertexes and edges of a graph that were replaced after a snapshot had been taken
+type graphVersion struct {
+	since, until int
+	vertexes     VertexSet
+	edges        EdgeSet
+}
+
+// Attributes of an edge that were replaced after a snapshot had been taken
+type attributesVersion struct {
+	since, until int
+	attributes   interface{}
+}
+
+// reports if any snapshot that is not released was taken between provided epochs
+func (g *Graph) needed(since, until int) bool {
+	for _, epoch := range g.snapshots {
+		if since <= epoch && epoch <= until {
+			return true
+		}
+	}
+	return false
+}
+
+// keeps current vertexes and edges of the graph for snapshots before they are changed
+func (g *Graph) preserve() {
+	if g.modified == g.epoch {
+		return
+	}
+	versions := g.versions[:0:0]
+	for _, version := range g.versions {
+		if g.needed(version.since, version.until) {
+			versions = append(versions, version)
+		}
+	}
+	if g.needed(g.modified, g.epoch-1) {
+		versions = append(versions, graphVersion{since: g.modified, until: g.epoch - 1, vertexes: g.vertexes, edges: g.edges})
+		g.vertexes, g.edges = g.vertexes.copy(), NewEdgeSet().Merge(g.edges)
+	}
+	g.versions, g.modified = versions, g.epoch
+}
+
+// returns vertexes and edges the graph had at provided epoch
+func (g *Graph) stateAt(epoch int) (VertexSet, EdgeSet) {
+	if g.modified <= epoch {
+		return g.vertexes, g.edges
+	}
+	for _, version := range g.versions {
+		if version.since <= epoch && epoch <= version.until {
+			return version.vertexes, version.edges
+		}
+	}
+	return NewVertexSet(), NewEdgeSet()
+}
+
+// keeps current edges of the vertex for snapshots before they are changed
+func (vtx *Vertex) preserve() {
+	g := vtx.graph
+	if g == nil || vtx.modified == g.epoch {
+		return
+	}
+	versions := vtx.versions[:0:0]
+	for _, version := range vtx.versions {
+		if g.needed(version.since, version.until) {
+			versions = append(versions, version)
+		}
+	}
+	if g.needed(vtx.modified, g.epoch-1) {
+		versions = append(versions, vertexVersion{
+			since:     vtx.modified,
+			until:     g.epoch - 1,
+			incoming:  vtx.incoming,
+			outcoming: vtx.outcoming,
+			adjacent:  vtx.adjacent,
+		})
+		vtx.incoming, vtx.outcoming = NewEdgeSet().Merge(vtx.incoming), NewEdgeSet().Merge(vtx.outcoming)
+		vtx.adjacent = vtx.adjacent.copy()
+	}
+	vtx.versions, vtx.modified = versions, g.epoch
+}
+
+// returns edges the vertex had at provided epoch
+func (vtx *Vertex) stateAt(epoch int) vertexVersion {
+	if vtx.modified <= epoch {
+		return vertexVersion{incoming: vtx.incoming, outcoming: vtx.outcoming, adjacent: vtx.adjacent}
+	}
+	for _, version := range vtx.versions {
+		if version.since <= epoch && epoch <= version.until {
+			return version
+		}
+	}
+	return vertexVersion{incoming: NewEdgeSet(), outcoming: NewEdgeSet(), adjacent: NewVertexSet()}
+}
+
+// keeps current attributes of the edge for snapshots before they are changed
+func (edge *Edge) preserve() {
+	g := edge.from.graph
+	if g == nil || edge.modified == g.epoch {
+		return
+	}
+	versions := edge.versions[:0:0]
+	for _, version := range edge.versions {
+		if g.needed(version.since, version.until) {
+			versions = append(versions, version)
+		}
+	}
+	if g.needed(edge.modified, g.epoch-1) {
+		versions = append(versions, attributesVersion{since: edge.modified, until: g.epoch - 1, attributes: edge.attributes})
+	}
+	edge.versions, edge.modified = versions, g.epoch
+}
+
+// returns attributes the edge had at provided epoch
+func (edge *Edge) attributesAt(epoch int) interface{} {
+	if edge.modified <= epoch {
+		return edge.attributes
+	}
+	for _, version := range edge.versions {
+		if version.since <= epoch && epoch <= version.until {
+			return version.attributes
+		}
+	}
+	return nil
+}
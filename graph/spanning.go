@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Algorithm of searching minimum spanning tree
+type SpanningAlgorithm int
+
+const (
+	// Kruskal's algorithm, edges are taken from the lightest one if they don't form a cycle
+	Kruskal = SpanningAlgorithm(iota)
+	// Prim's algorithm, tree grows from a vertex by the lightest edge that leads out of it
+	Prim
+)
+
+// Returns edges of minimum spanning forest of vertexes of the set: a tree with the smallest total weight
+// for each connected component. Only edges between vertexes of the set are considered and their direction is ignored.
+// Edges of the same weight are taken in order they were added, so result doesn't change between calls.
+// To traverse only the forest use `OverEdges` option with `Contains` method of the result.
+func MinimumSpanningForest(vs VertexSet, algorithm SpanningAlgorithm, weight EdgeWeight) EdgeSet {
+	switch algorithm {
+	case Kruskal:
+		return kruskalForest(vs, weight)
+	case Prim:
+		return primForest(vs, weight)
+	default:
+		return NewEdgeSet()
+	}
+}
+
+func kruskalForest(vs VertexSet, weight EdgeWeight) EdgeSet {
+	var edges []*Edge
+	weights := map[*Edge]float64{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		for _, end := range vtx.ownOutcomingEnds() {
+			if end.vertex != vtx && vs.Contains(end.vertex) {
+				edges = append(edges, end.edge)
+				weights[end.edge] = weight(end.edge)
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return weights[edges[i]] < weights[edges[j]]
+	})
+
+	forest := NewEdgeSet()
+	trees := disjointSets{}
+	for _, edge := range edges {
+		if trees.union(edge.from, edge.to) {
+			forest.put(edgeEnd{edge: edge, vertex: edge.to})
+		}
+	}
+	return forest
+}
+
+func primForest(vs VertexSet, weight EdgeWeight) EdgeSet {
+	forest := NewEdgeSet()
+	inTree := NewVertexSet()
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		root := iterator.Next()
+		if inTree.Contains(root) {
+			continue
+		}
+		queue := &costQueue{}
+		heap.Push(queue, costQueueItem{vtx: root})
+		for queue.Len() > 0 {
+			item := heap.Pop(queue).(costQueueItem)
+			if inTree.Contains(item.vtx) {
+				continue
+			}
+			inTree.put(item.vtx)
+			if item.edge != nil {
+				forest.put(edgeEnd{edge: item.edge, vertex: item.edge.to})
+			}
+			for _, end := range item.vtx.incidentEnds() {
+				if vs.Contains(end.vertex) && !inTree.Contains(end.vertex) {
+					edgeWeight := weight(end.edge)
+					heap.Push(queue, costQueueItem{vtx: end.vertex, edge: end.edge, cost: edgeWeight, priority: edgeWeight})
+				}
+			}
+		}
+	}
+	return forest
+}
+
+// Union-find structure over vertexes, vertex that was never united is a set on its own
+type disjointSets map[*Vertex]*Vertex
+
+func (ds disjointSets) find(vtx *Vertex) *Vertex {
+	parent, found := ds[vtx]
+	if !found || parent == vtx {
+		return vtx
+	}
+	root := ds.find(parent)
+	ds[vtx] = root
+	return root
+}
+
+// joins sets of both vertexes, reports false if they were already in the same set
+func (ds disjointSets) union(one, another *Vertex) bool {
+	oneRoot, anotherRoot := ds.find(one), ds.find(another)
+	if oneRoot == anotherRoot {
+		return false
+	}
+	ds[anotherRoot] = oneRoot
+	return true
+}
@@ -0,0 +1,88 @@
+package graph
+
+// Returns new graph with copies of all vertexes and edges of the graph in the same order.
+// Data of vertexes and attributes of edges are not copied, so copies share them with the graph.
+func (g *Graph) Clone() *Graph {
+	return Subgraph(g.Vertexes())
+}
+
+// Copies the vertex together with all vertexes connected to it in any direction and edges between them,
+// returns copy of the vertex. Copies are free vertexes, data of vertexes and attributes of edges are shared.
+func (vtx *Vertex) Clone() *Vertex {
+	connected := NewVertexSet()
+	for iterator := BFS.StartAt(vtx, InDirection(BothDirections)); iterator.HasNext(); {
+		connected.put(iterator.Next())
+	}
+	return copyVertexes(nil, connected, inducedEdges(connected))[vtx]
+}
+
+// Returns new graph with copies of vertexes of the set and all edges between them: subgraph induced by the set.
+// Vertexes keep order of the set and edges keep order of the graph, data of vertexes and attributes of edges
+// are shared with the original graph.
+func Subgraph(vs VertexSet) *Graph {
+	g := NewGraph()
+	copyVertexes(g, vs, inducedEdges(vs))
+	return g
+}
+
+// Returns new graph with copies of edges of the graph that satisfy the predicate and vertexes they connect.
+// Vertexes and edges keep their order, data of vertexes and attributes of edges are shared with the graph.
+func (g *Graph) EdgeSubgraph(predicate EdgePredicate) *Graph {
+	var edges []*Edge
+	ends := NewVertexSet()
+	for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+		if edge := iterator.Next(); predicate(edge) {
+			edges = append(edges, edge)
+			ends.put(edge.from)
+			ends.put(edge.to)
+		}
+	}
+	subgraph := NewGraph()
+	copyVertexes(subgraph, g.Vertexes().Intersect(ends), edges)
+	return subgraph
+}
+
+// copies vertexes of the set into the graph, or as free vertexes if it is `nil`, and links copies with copies
+// of provided edges in their order, returns copies of vertexes
+func copyVertexes(g *Graph, vs VertexSet, edges []*Edge) map[*Vertex]*Vertex {
+	copies := map[*Vertex]*Vertex{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		copies[vtx] = VertexWith(vtx.data)
+		if g != nil {
+			g.Add(copies[vtx])
+		}
+	}
+	for _, edge := range edges {
+		link(&Edge{from: copies[edge.from], to: copies[edge.to], attributes: edge.Attributes(), undirected: edge.undirected})
+	}
+	return copies
+}
+
+// returns edges between vertexes of the set, edges of a graph are in order of the graph
+// and edges of free vertexes are in order of outcoming edges of the vertexes
+func inducedEdges(vs VertexSet) (edges []*Edge) {
+	graphs := map[*Graph]bool{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		g := vtx.Graph()
+		if g == nil {
+			for _, end := range vtx.ownOutcomingEnds() {
+				if vs.Contains(end.vertex) {
+					edges = append(edges, end.edge)
+				}
+			}
+			continue
+		}
+		if graphs[g] {
+			continue
+		}
+		graphs[g] = true
+		for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+			if edge := iterator.Next(); vs.Contains(edge.from) && vs.Contains(edge.to) {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// Algorithm of topological sorting
+type TopologicalAlgorithm int
+
+const (
+	// Kahn's algorithm: vertexes without incoming edges are taken one by one,
+	// if there are several such vertexes the one added to the set earlier goes first
+	Kahn = TopologicalAlgorithm(iota)
+	// Depth-first search based algorithm: vertexes are ordered in reverse post-order,
+	// search starts from vertexes in order they were added to the set
+	DepthFirst
+)
+
+// Error returned when vertexes can't be sorted because there is a cycle between them
+type CycleError struct {
+	// vertexes of the found cycle, each of them has an edge to the following one and the last one has an edge to the first one
+	Cycle []*Vertex
+}
+
+func (ce *CycleError) Error() string {
+	return fmt.Sprintf("graph: not a directed acyclic graph, found cycle of %d vertexes", len(ce.Cycle))
+}
+
+// Orders vertexes of the set so that each edge between them leads from an earlier vertex to a later one.
+// Only edges between vertexes of the set are considered, undirected edge is treated as a cycle of two vertexes.
+// Returns `*CycleError` if it is not possible.
+func TopologicalSort(vs VertexSet, algorithm TopologicalAlgorithm) ([]*Vertex, error) {
+	switch algorithm {
+	case Kahn:
+		return kahnSort(vs)
+	case DepthFirst:
+		return depthFirstSort(vs)
+	default:
+		return nil, fmt.Errorf("graph: unknown topological algorithm %d", algorithm)
+	}
+}
+
+// Orders the vertex and all vertexes reachable from it, see `TopologicalSort`
+func (vtx *Vertex) TopologicalSort(algorithm TopologicalAlgorithm) ([]*Vertex, error) {
+	return TopologicalSort(reachable(vtx), algorithm)
+}
+
+// Orders all vertexes of the graph, see `TopologicalSort`
+func (g *Graph) TopologicalSort(algorithm TopologicalAlgorithm) ([]*Vertex, error) {
+	return TopologicalSort(g.Vertexes(), algorithm)
+}
+
+func kahnSort(vs VertexSet) ([]*Vertex, error) {
+	indegree := map[*Vertex]int{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		for _, end := range iterator.Next().outcomingEnds() {
+			if vs.Contains(end.vertex) {
+				indegree[end.vertex]++
+			}
+		}
+	}
+
+	ready := &indexQueue{}
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		if vtx := iterator.Next(); indegree[vtx] == 0 {
+			heap.Push(ready, vs.set[vtx])
+		}
+	}
+
+	sorted := make([]*Vertex, 0, vs.Len())
+	for ready.Len() > 0 {
+		vtx := vs.order[heap.Pop(ready).(int)]
+		sorted = append(sorted, vtx)
+		for _, end := range vtx.outcomingEnds() {
+			if !vs.Contains(end.vertex) {
+				continue
+			}
+			indegree[end.vertex]--
+			if indegree[end.vertex] == 0 {
+				heap.Push(ready, vs.set[end.vertex])
+			}
+		}
+	}
+	if len(sorted) == vs.Len() {
+		return sorted, nil
+	}
+
+	// each of left vertexes has an incoming edge from another left vertex,
+	// so going back over such edges leads to a cycle
+	var start *Vertex
+	for iterator := vs.Iterator(); start == nil; {
+		if vtx := iterator.Next(); indegree[vtx] > 0 {
+			start = vtx
+		}
+	}
+	var walk []*Vertex
+	positions := map[*Vertex]int{}
+	for vtx := start; ; {
+		if position, found := positions[vtx]; found {
+			cycle := walk[position:]
+			reverseVertexes(cycle)
+			return nil, &CycleError{Cycle: cycle}
+		}
+		positions[vtx] = len(walk)
+		walk = append(walk, vtx)
+		for _, end := range vtx.incomingEnds() {
+			if vs.Contains(end.vertex) && indegree[end.vertex] > 0 {
+				vtx = end.vertex
+				break
+			}
+		}
+	}
+}
+
+func depthFirstSort(vs VertexSet) ([]*Vertex, error) {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	states := map[*Vertex]int{}
+	postOrder := make([]*Vertex, 0, vs.Len())
+	for iterator := vs.Iterator(); iterator.HasNext(); {
+		root := iterator.Next()
+		if states[root] != unvisited {
+			continue
+		}
+		states[root] = inProgress
+		stack := []dfFrame{{vtx: root, ends: root.outcomingEnds()}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.index == len(top.ends) {
+				states[top.vtx] = done
+				postOrder = append(postOrder, top.vtx)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			end := top.ends[top.index]
+			top.index++
+			if !vs.Contains(end.vertex) {
+				continue
+			}
+			switch states[end.vertex] {
+			case unvisited:
+				states[end.vertex] = inProgress
+				stack = append(stack, dfFrame{vtx: end.vertex, ends: end.vertex.outcomingEnds()})
+			case inProgress:
+				// edge leads back to a vertex on the stack, so vertexes from it to the top form a cycle
+				var cycle []*Vertex
+				for i := len(stack) - 1; stack[i].vtx != end.vertex; i-- {
+					cycle = append(cycle, stack[i].vtx)
+				}
+				cycle = append(cycle, end.vertex)
+				reverseVertexes(cycle)
+				return nil, &CycleError{Cycle: cycle}
+			}
+		}
+	}
+
+	reverseVertexes(postOrder)
+	return postOrder, nil
+}
+
+// Priority queue of positions of vertexes in a set, the smallest position is popped first
+type indexQueue []int
+
+func (iq indexQueue) Len() int {
+	return len(iq)
+}
+
+func (iq indexQueue) Less(i, j int) bool {
+	return iq[i] < iq[j]
+}
+
+func (iq indexQueue) Swap(i, j int) {
+	iq[i], iq[j] = iq[j], iq[i]
+}
+
+func (iq *indexQueue) Push(index interface{}) {
+	*iq = append(*iq, index.(int))
+}
+
+func (iq *indexQueue) Pop() interface{} {
+	last := len(*iq) - 1
+	index := (*iq)[last]
+	*iq = (*iq)[:last]
+	return index
+}
@@ -0,0 +1,100 @@
+package graph
+
+// Returns new graph with copies of vertexes of the graph where directed edges are reversed and undirected edges are kept.
+// Vertexes and edges keep their order, data of vertexes and attributes of edges are shared with the graph.
+func (g *Graph) Transpose() *Graph {
+	transposed := NewGraph()
+	copies := map[*Vertex]*Vertex{}
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		copies[vtx] = transposed.AddVertex(vtx.data)
+	}
+	for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+		edge := iterator.Next()
+		from, to := copies[edge.from], copies[edge.to]
+		if !edge.undirected {
+			from, to = to, from
+		}
+		link(&Edge{from: from, to: to, attributes: edge.Attributes(), undirected: edge.undirected})
+	}
+	return transposed
+}
+
+// Returns graph with a vertex for each edge of the graph, data of the vertex is the edge.
+// Vertexes are connected if the path can go over one edge and then over another one, attributes of such edge is
+// the vertex where both edges meet. Edges of vertexes for undirected edges are undirected, other ones are directed.
+func (g *Graph) LineGraph() *Graph {
+	line := NewGraph()
+	vertexOf := map[*Edge]*Vertex{}
+	edges := g.Edges()
+	for iterator := edges.Iterator(); iterator.HasNext(); {
+		edge := iterator.Next()
+		vertexOf[edge] = line.AddVertex(edge)
+	}
+	linked := map[[2]*Vertex]bool{}
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		for _, in := range vtx.incomingEnds() {
+			for _, out := range vtx.outcomingEnds() {
+				from, to := vertexOf[in.edge], vertexOf[out.edge]
+				undirected := in.edge.undirected && out.edge.undirected
+				if from == to || linked[[2]*Vertex{from, to}] {
+					continue
+				}
+				linked[[2]*Vertex{from, to}] = true
+				if undirected {
+					linked[[2]*Vertex{to, from}] = true
+				}
+				link(&Edge{from: from, to: to, attributes: vtx, undirected: undirected})
+			}
+		}
+	}
+	return line
+}
+
+// Returns new graph where vertexes connected by the edge are merged into a single vertex with data of the vertex
+// the edge starts at. The edge is removed and other edges between merged vertexes become self-loops.
+// Vertexes and edges keep their order, data of vertexes and attributes of edges are shared with the graph.
+// Panics if the edge doesn't belong to the graph.
+func (g *Graph) Contract(edge *Edge) *Graph {
+	if edge.from.Graph() != g || !edge.from.Outcoming().Contains(edge) {
+		panic("graph: edge doesn't belong to the graph")
+	}
+	contracted := NewGraph()
+	copies := map[*Vertex]*Vertex{}
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		// contracted self-loop leaves the vertex as it is
+		if vtx != edge.to || edge.to == edge.from {
+			copies[vtx] = contracted.AddVertex(vtx.data)
+		}
+	}
+	copies[edge.to] = copies[edge.from]
+	for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+		if other := iterator.Next(); other != edge {
+			link(&Edge{from: copies[other.from], to: copies[other.to], attributes: other.Attributes(), undirected: other.undirected})
+		}
+	}
+	return contracted
+}
+
+// Returns graph with a vertex for each group, data of the vertex is the group.
+// Groups are connected with an edge if there are outcoming edges from vertexes of one group to vertexes of another one,
+// attributes of such edge is `EdgeSet` with all these edges. Edges inside groups and edges to vertexes
+// that are not in any group are skipped, a vertex that is in several groups belongs to the first one.
+func Quotient(groups []GroupedVertexes) *Graph {
+	g := NewGraph()
+	groupOf := map[*Vertex]*Vertex{}
+	grouped := NewVertexSet()
+	for _, group := range groups {
+		gVtx := g.AddVertex(group)
+		for _, vtx := range group.Vertexes {
+			if !grouped.Contains(vtx) {
+				grouped.put(vtx)
+				groupOf[vtx] = gVtx
+			}
+		}
+	}
+	linkGroups(grouped, groupOf)
+	return g
+}
@@ -16,3 +16,28 @@ func (vtx *Vertex) TraverseWith(strategy TraversingStrategy, action Action) (err
 	}
 	return
 }
+
+// Describes rules how vertexes in graph must be searched together with paths they were reached by
+type PathTraversingStrategy interface {
+	TraversingStrategy
+	// Starts searching from provided vertex, each path starts at it and ends at found vertex
+	SearchPaths(vtx *Vertex) []Path
+}
+
+// Search throw the graph with provided `strategy` and apply `action` to paths of found vertexes
+func (vtx *Vertex) TraversePathsWith(strategy PathTraversingStrategy, action PathAction) (err error) {
+	paths := strategy.SearchPaths(vtx)
+	for _, path := range paths {
+		if err = action(path); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Describes rules how the cheapest path between vertexes must be searched
+type ShortestPathStrategy interface {
+	PathTraversingStrategy
+	// Returns the cheapest path from provided vertex to the target one
+	ShortestPath(vtx *Vertex) (Path, error)
+}
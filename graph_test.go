@@ -3,6 +3,10 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/pavelmemory/mgraph/graph"
@@ -167,6 +171,103 @@ func TestGraph_FindAll(t *testing.T) {
 	}
 }
 
+func TestGraph_FindOnCyclicGraph(t *testing.T) {
+	v0 := graph.VertexWith(0)
+	v1 := graph.VertexWith(1)
+	v2 := graph.VertexWith(2)
+	v0.EdgeTo(v1)
+	v1.EdgeTo(v2).Edge(v0)
+	v2.EdgeTo(v0).EdgeTo(v2)
+
+	for _, algorithm := range []graph.SearchAlgorithm{graph.DFS, graph.BFS} {
+		var searchChecks int
+		err := v0.TraverseWith(graph.FindN(algorithm, 5, func(vtx *graph.Vertex) bool {
+			searchChecks++
+			return true
+		}), func(vtx *graph.Vertex) error { return nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		if searchChecks != 3 {
+			t.Errorf("algorithm %d: wrong amount of search checks: %d", algorithm, searchChecks)
+		}
+
+		var first []int
+		err = v1.TraverseWith(graph.FindFirst(algorithm, func(vtx *graph.Vertex) bool {
+			return vtx.Data().(int) == 0
+		}), func(vtx *graph.Vertex) error {
+			first = append(first, vtx.Data().(int))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(first) != 1 || first[0] != 0 {
+			t.Errorf("algorithm %d: unexpected result: %v", algorithm, first)
+		}
+	}
+
+	var searchChecks int
+	var found int
+	err := v2.TraverseWith(graph.FindAll(func(vtx *graph.Vertex) bool {
+		searchChecks++
+		return vtx.Data().(int) != 2
+	}), func(vtx *graph.Vertex) error {
+		found++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searchChecks != 3 {
+		t.Errorf("wrong amount of search checks: %d", searchChecks)
+	}
+	if found != 2 {
+		t.Errorf("wrong amount of found vertexes: %d", found)
+	}
+}
+
+func TestGraph_FindOnDiamondGraph(t *testing.T) {
+	v3 := graph.VertexWith(3)
+	v0 := graph.VertexWith(0).
+		EdgeTo(graph.VertexWith(1).EdgeTo(v3)).
+		EdgeTo(graph.VertexWith(2).EdgeTo(v3))
+
+	expectedOrders := map[graph.SearchAlgorithm][]int{
+		graph.DFS: {3, 1, 2, 0},
+		graph.BFS: {0, 1, 2, 3},
+	}
+	for algorithm, expected := range expectedOrders {
+		var order []int
+		err := v0.TraverseWith(graph.FindN(algorithm, 10, func(vtx *graph.Vertex) bool {
+			return true
+		}), func(vtx *graph.Vertex) error {
+			order = append(order, vtx.Data().(int))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(order) != fmt.Sprint(expected) {
+			t.Errorf("algorithm %d: unexpected order of vertexes: %v", algorithm, order)
+		}
+	}
+
+	var found int
+	err := v0.TraverseWith(graph.FindAll(func(vtx *graph.Vertex) bool {
+		return vtx.Data().(int) == 3
+	}), func(vtx *graph.Vertex) error {
+		found++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != 1 {
+		t.Errorf("vertex must be found only once: %d", found)
+	}
+}
+
 func TestGraph_GroupOutcoming(t *testing.T) {
 	v := graph.VertexWith(0).
 		EdgeTo(graph.VertexWith(1)).
@@ -179,7 +280,7 @@ func TestGraph_GroupOutcoming(t *testing.T) {
 	var odd int
 	var groups int
 	err := v.GroupOutcomingBy(func(edge *graph.Edge) []byte {
-		if edge.Vertex().Data().(int)%2 == 0 {
+		if edge.To().Data().(int)%2 == 0 {
 			even++
 			return []byte{0}
 		}
@@ -225,7 +326,7 @@ func TestGraph_Group(t *testing.T) {
 		odd := 1
 		var groups int
 		err := v.GroupBy(func(edge *graph.Edge) []byte {
-			if edge.Vertex().Data().(int)%2 == 0 {
+			if edge.Other(v).Data().(int)%2 == 0 {
 				return []byte{0}
 			}
 			return []byte{1}
@@ -257,7 +358,7 @@ func TestGraph_Group(t *testing.T) {
 
 	t.Run("GroupedBy", func(t *testing.T) {
 		groups := v.GroupedBy(func(edge *graph.Edge) []byte {
-			if edge.Vertex().Data().(int)%2 == 0 {
+			if edge.Other(v).Data().(int)%2 == 0 {
 				return []byte{0}
 			}
 			return []byte{1}
@@ -364,7 +465,7 @@ func TestGraph_GroupGroup(t *testing.T) {
 		VertexesSet().
 		OutcomingWhich(graph.EdgeAttributeEqualsTo(product)).
 		GroupedBy(func(edge *graph.Edge) []byte {
-			return []byte(edge.Vertex().Data().(*Product).Code)
+			return []byte(edge.To().Data().(*Product).Code)
 		})
 	for _, groupedProductEdge := range groupedProductEdges {
 		fmt.Println(string(groupedProductEdge.GroupKey))
@@ -520,3 +621,1626 @@ func TestExistVertexes(t *testing.T) {
 		t.Errorf("unexpected group key '%s'", string(gg[0].GroupKey))
 	}
 }
+
+func TestGraph_GroupVertexesWithPathSemantics(t *testing.T) {
+	const link = "link"
+	a := graph.VertexWith("a")
+	b := graph.VertexWith("b")
+	c := graph.VertexWith("c")
+	a.EdgeToWith(b, link)
+	b.EdgeToWith(c, link)
+	c.EdgeToWith(a, link)
+
+	byData := func(vtx *graph.Vertex) []byte {
+		return []byte(vtx.Data().(string))
+	}
+	keys := func(groups []graph.GroupedVertexes) map[string]bool {
+		res := map[string]bool{}
+		for _, group := range groups {
+			res[string(group.GroupKey)] = true
+		}
+		return res
+	}
+
+	twoHops := graph.GoOverEdge(graph.EdgeAttributeEqualsTo(link)).GoOverEdge(graph.EdgeAttributeEqualsTo(link))
+	if groups := keys(a.GroupVertexes(twoHops.GroupVertexesWith(byData))); !groups["a"] {
+		t.Errorf("walk must return back to the start vertex: %v", groups)
+	}
+	for _, semantics := range []graph.PathSemantics{graph.Trail, graph.SimplePath} {
+		groups := keys(a.GroupVertexes(twoHops.WithSemantics(semantics).GroupVertexesWith(byData)))
+		if len(groups) != 2 || !groups["b"] || !groups["c"] {
+			t.Errorf("semantics %d: unexpected vertexes: %v", semantics, groups)
+		}
+	}
+
+	threeHops := twoHops.GoOverEdge(graph.EdgeAttributeEqualsTo(link))
+	if groups := keys(a.GroupVertexes(threeHops.WithSemantics(graph.Trail).GroupVertexesWith(byData))); len(groups) != 1 || !groups["a"] {
+		t.Errorf("trail must go around the cycle: %v", groups)
+	}
+	if groups := a.GroupVertexes(threeHops.WithSemantics(graph.SimplePath).GroupVertexesWith(byData)); len(groups) != 0 {
+		t.Errorf("simple path must not revisit vertexes: %v", keys(groups))
+	}
+
+	isStart := func(vtx *graph.Vertex) bool { return vtx == a }
+	if !a.ExistVertexes(twoHops.ExistVertexesWith(isStart)) {
+		t.Error("walk must reach the start vertex")
+	}
+	if a.ExistVertexes(twoHops.WithSemantics(graph.Trail).ExistVertexesWith(isStart)) {
+		t.Error("trail must not reach the start vertex in two hops")
+	}
+}
+
+func TestGraph_Container(t *testing.T) {
+	g := graph.NewGraph()
+	if g.Order() != 0 || g.Size() != 0 {
+		t.Fatalf("new graph must be empty: %d vertexes, %d edges", g.Order(), g.Size())
+	}
+
+	v0 := g.AddVertex(0).
+		EdgeTo(graph.VertexWith(1).
+			EdgeTo(graph.VertexWith(2))).
+		EdgeTo(graph.VertexWith(3))
+	v4 := g.AddVertex(4)
+	v4.Edge(g.AddVertex(5))
+
+	if v0.Graph() != g || v4.Graph() != g {
+		t.Error("vertexes must belong to the graph")
+	}
+	if g.Order() != 6 {
+		t.Errorf("unexpected amount of vertexes: %d", g.Order())
+	}
+	if g.Size() != 4 {
+		t.Errorf("unexpected amount of edges: %d", g.Size())
+	}
+
+	var data []int
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		vtx := iterator.Next()
+		if vtx.Graph() != g {
+			t.Errorf("vertex %v doesn't belong to the graph", vtx.Data())
+		}
+		data = append(data, vtx.Data().(int))
+	}
+	if fmt.Sprint(data) != "[0 1 2 3 4 5]" {
+		t.Errorf("unexpected vertexes: %v", data)
+	}
+
+	free := graph.VertexWith(6).EdgeTo(graph.VertexWith(7))
+	if free.Graph() != nil {
+		t.Error("vertex must not belong to any graph")
+	}
+	g.Add(free)
+	if g.Order() != 8 || g.Size() != 5 {
+		t.Errorf("unexpected graph after adding a free vertex: %d vertexes, %d edges", g.Order(), g.Size())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("linking vertexes of different graphs must panic")
+		}
+	}()
+	v0.EdgeTo(graph.NewGraph().AddVertex(8))
+}
+
+func TestGraph_Remove(t *testing.T) {
+	g := graph.NewGraph()
+	v0 := g.AddVertex(0)
+	v1 := g.AddVertex(1)
+	v2 := g.AddVertex(2)
+	v0.EdgeToWith(v1, "a").EdgeToWith(v1, "b").EdgeTo(v2)
+	v1.EdgeTo(v0)
+	v2.Edge(v1)
+
+	edges := v0.OutcomingWhich(graph.EdgeAttributeEqualsTo("a")).Iterator()
+	v0.RemoveEdge(edges.Next())
+	if v0.EdgesTo(v1).Len() != 1 || v1.Incoming().Len() != 2 {
+		t.Errorf("unexpected edges after removal: %d outcoming, %d incoming", v0.EdgesTo(v1).Len(), v1.Incoming().Len())
+	}
+	if !v0.Adjacent().Contains(v1) || !v1.Adjacent().Contains(v0) {
+		t.Error("vertexes connected with parallel edge must stay adjacent")
+	}
+	if g.Size() != 4 {
+		t.Errorf("unexpected amount of edges in the graph: %d", g.Size())
+	}
+
+	v0.RemoveEdgesTo(v1)
+	if v0.EdgesTo(v1).Len() != 0 {
+		t.Errorf("unexpected edges after removal: %d", v0.EdgesTo(v1).Len())
+	}
+	if !v0.Adjacent().Contains(v1) {
+		t.Error("vertexes connected with incoming edge must stay adjacent")
+	}
+	edges = v1.EdgesTo(v0).Iterator()
+	v1.RemoveEdge(edges.Next())
+	if v0.Adjacent().Contains(v1) || v1.Adjacent().Contains(v0) {
+		t.Error("vertexes without edges must not be adjacent")
+	}
+
+	v1.Detach()
+	if v1.Graph() != nil || v1.Adjacent().Len() != 0 || v1.Incoming().Len() != 0 || v1.Outcoming().Len() != 0 {
+		t.Error("detached vertex must not have edges nor graph")
+	}
+	if v2.Adjacent().Len() != 1 || v2.Incoming().Len() != 1 || v2.Outcoming().Len() != 0 {
+		t.Errorf("unexpected edges of the vertex: %d incoming, %d outcoming", v2.Incoming().Len(), v2.Outcoming().Len())
+	}
+	if g.Order() != 2 || g.Size() != 1 {
+		t.Errorf("unexpected graph after detach: %d vertexes, %d edges", g.Order(), g.Size())
+	}
+
+	var data []int
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		data = append(data, iterator.Next().Data().(int))
+	}
+	for iterator := v2.Adjacent().Iterator(); iterator.HasNext(); {
+		data = append(data, iterator.Next().Data().(int))
+	}
+	if fmt.Sprint(data) != "[0 2 0]" {
+		t.Errorf("unexpected vertexes: %v", data)
+	}
+
+	hub := g.AddVertex(-1)
+	leaves := make([]*graph.Vertex, 10)
+	for i := range leaves {
+		leaves[i] = g.AddVertex(10 + i)
+		hub.EdgeToWith(leaves[i], i)
+	}
+	removed := hub.Outcoming().Iterator()
+	for i := range leaves {
+		edge := removed.Next()
+		if i%3 != 0 {
+			leaves[i].Detach()
+			if hub.Outcoming().Contains(edge) || g.Edges().Contains(edge) {
+				t.Errorf("edge to detached vertex %d must be removed", i)
+			}
+		}
+	}
+	hub.EdgeToWith(v0, 10)
+	data = nil
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		data = append(data, iterator.Next().Data().(int))
+	}
+	for iterator := hub.Outcoming().Iterator(); iterator.HasNext(); {
+		data = append(data, iterator.Next().Attributes().(int))
+	}
+	if fmt.Sprint(data) != "[0 2 -1 10 13 16 19 0 3 6 9 10]" || g.Size() != 6 {
+		t.Errorf("removals must keep order of vertexes and edges: %v", data)
+	}
+}
+
+func TestGraph_SharedEdge(t *testing.T) {
+	v0 := graph.VertexWith(0)
+	v1 := graph.VertexWith(1)
+	v0.EdgeToWith(v1, "before")
+
+	outcoming := v0.Outcoming().Iterator()
+	incoming := v1.Incoming().Iterator()
+	edge := outcoming.Next()
+	if edge != incoming.Next() {
+		t.Fatal("both vertexes must share the same edge")
+	}
+	if edge.From() != v0 || edge.To() != v1 || !edge.Directed() {
+		t.Errorf("unexpected ends of the edge: %v -> %v", edge.From().Data(), edge.To().Data())
+	}
+	edge.SetAttributes("after")
+	if v1.IncomingWhich(graph.EdgeAttributeEqualsTo("after")).Len() != 1 {
+		t.Error("attributes must be updated for both vertexes")
+	}
+	if vtxs := v1.Incoming().Vertexes(); len(vtxs) != 1 || vtxs[0] != v0 {
+		t.Error("incoming edges must lead to the vertex they come from")
+	}
+
+	v2 := graph.VertexWith(2)
+	v1.EdgeWith(v2, "undirected")
+	undirected := v2.Outcoming().Iterator()
+	edge = undirected.Next()
+	if edge.Directed() || edge.Other(v2) != v1 || edge.Other(v1) != v2 {
+		t.Error("unexpected undirected edge")
+	}
+	if v1.EdgesTo(v2).Len() != 1 || v2.EdgesTo(v1).Len() != 1 {
+		t.Error("undirected edge must lead in both directions")
+	}
+
+	var order []int
+	err := v0.TraverseWith(graph.FindAll(func(vtx *graph.Vertex) bool { return true }), func(vtx *graph.Vertex) error {
+		order = append(order, vtx.Data().(int))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(order) != "[0 1 2]" {
+		t.Errorf("unexpected vertexes: %v", order)
+	}
+
+	v1.RemoveEdge(edge)
+	if v2.Outcoming().Len() != 0 || v2.Incoming().Len() != 0 || v1.Adjacent().Contains(v2) {
+		t.Error("undirected edge must be removed from both vertexes")
+	}
+}
+
+func TestGraph_ShortestPath(t *testing.T) {
+	weight := func(edge *graph.Edge) float64 {
+		return edge.Attributes().(float64)
+	}
+	a := graph.VertexWith("a")
+	b := graph.VertexWith("b")
+	c := graph.VertexWith("c")
+	d := graph.VertexWith("d")
+	e := graph.VertexWith("e")
+	a.EdgeToWith(b, 4.0).EdgeToWith(c, 1.0)
+	c.EdgeToWith(b, 2.0).EdgeToWith(d, 7.0)
+	b.EdgeToWith(d, 1.0)
+	d.EdgeWith(e, 3.0)
+
+	pathData := func(path graph.Path) (res string) {
+		for _, vtx := range path.Vertexes() {
+			res += vtx.Data().(string)
+		}
+		return
+	}
+
+	strategies := map[string]graph.ShortestPathStrategy{
+		"Dijkstra":    graph.Dijkstra(e, weight),
+		"AStar":       graph.AStar(e, weight, func(vtx *graph.Vertex) float64 { return 0.5 }),
+		"BellmanFord": graph.BellmanFord(e, weight),
+	}
+	for name, strategy := range strategies {
+		path, err := strategy.ShortestPath(a)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if pathData(path) != "acbde" || path.Cost() != 7 || path.Len() != 4 || len(path.Edges()) != 4 {
+			t.Errorf("%s: unexpected path %s with cost %v", name, pathData(path), path.Cost())
+		}
+		if path.Edges()[3].Directed() {
+			t.Errorf("%s: last edge of the path must be undirected", name)
+		}
+		if vtxs := strategy.Search(a); len(vtxs) != 5 {
+			t.Errorf("%s: unexpected amount of vertexes: %d", name, len(vtxs))
+		}
+		if _, err := strategy.ShortestPath(graph.VertexWith("f")); err != graph.ErrUnreachable {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	c.EdgeToWith(e, -5.0)
+	if _, err := graph.Dijkstra(e, weight).ShortestPath(a); err != graph.ErrNegativeWeight {
+		t.Errorf("unexpected error: %v", err)
+	}
+	path, err := graph.BellmanFord(d, weight).ShortestPath(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pathData(path) != "aced" || path.Cost() != -1 {
+		t.Errorf("unexpected path %s with cost %v", pathData(path), path.Cost())
+	}
+
+	d.EdgeToWith(a, -1.0)
+	if _, err := graph.BellmanFord(e, weight).ShortestPath(a); err != graph.ErrNegativeCycle {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGraph_TraversePaths(t *testing.T) {
+	const (
+		product = "product"
+		metric  = "metric"
+	)
+	units := graph.VertexWith("units")
+	simulation := graph.VertexWith("simulation").
+		EdgeToWith(graph.VertexWith("coke").EdgeToWith(units, metric), product).
+		EdgeToWith(graph.VertexWith("pepsi").EdgeToWith(graph.VertexWith("sales"), metric), product)
+
+	isMetric := func(vtx *graph.Vertex) bool {
+		return vtx.Incoming().Len() > 0 && vtx.Incoming().Vertexes()[0].Incoming().Len() > 0
+	}
+	strategies := map[string]graph.PathTraversingStrategy{
+		"DFS":   graph.FindN(graph.DFS, 2, isMetric),
+		"BFS":   graph.FindN(graph.BFS, 2, isMetric),
+		"First": graph.FindFirst(graph.BFS, isMetric),
+		"All":   graph.FindAll(isMetric),
+	}
+	for name, strategy := range strategies {
+		var routes []string
+		err := simulation.TraversePathsWith(strategy, func(path graph.Path) error {
+			if path.Start() != simulation || path.Len() != 2 || path.Cost() != 2 {
+				t.Errorf("%s: unexpected path of %d edges with cost %v", name, path.Len(), path.Cost())
+			}
+			edges := path.Edges()
+			if edges[0].Attributes() != product || edges[1].Attributes() != metric {
+				t.Errorf("%s: unexpected edges %v, %v", name, edges[0].Attributes(), edges[1].Attributes())
+			}
+			var route string
+			for _, vtx := range path.Vertexes() {
+				route += "/" + vtx.Data().(string)
+			}
+			routes = append(routes, route)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if routes[0] != "/simulation/coke/units" {
+			t.Errorf("%s: unexpected routes: %v", name, routes)
+		}
+
+		vtxs := strategy.Search(simulation)
+		if len(vtxs) != len(routes) || vtxs[0] != units {
+			t.Errorf("%s: search must return vertexes paths end at", name)
+		}
+	}
+}
+
+func TestGraph_TraversalOptions(t *testing.T) {
+	const (
+		product = "product"
+		brand   = "brand"
+	)
+	v0 := graph.VertexWith(0)
+	v1 := graph.VertexWith(1)
+	v2 := graph.VertexWith(2)
+	v3 := graph.VertexWith(3)
+	v4 := graph.VertexWith(4)
+	v0.EdgeToWith(v1, product).EdgeToWith(v4, brand)
+	v1.EdgeToWith(v2, product)
+	v2.EdgeToWith(v3, product)
+	v3.EdgeToWith(v0, product)
+
+	all := func(vtx *graph.Vertex) bool { return true }
+	data := func(vtxs []*graph.Vertex) (res []int) {
+		for _, vtx := range vtxs {
+			res = append(res, vtx.Data().(int))
+		}
+		return
+	}
+
+	found := graph.FindAll(all, graph.MaxDepth(2), graph.OverEdges(graph.EdgeAttributeEqualsTo(product))).Search(v0)
+	if fmt.Sprint(data(found)) != "[0 1 2]" {
+		t.Errorf("unexpected vertexes within 2 hops: %v", data(found))
+	}
+
+	found = graph.FindAll(all, graph.MaxDepth(1), graph.InDirection(graph.IncomingDirection)).Search(v0)
+	if fmt.Sprint(data(found)) != "[0 3]" {
+		t.Errorf("unexpected vertexes over incoming edges: %v", data(found))
+	}
+
+	found = graph.FindAll(all, graph.MaxDepth(1), graph.InDirection(graph.BothDirections)).Search(v0)
+	if fmt.Sprint(data(found)) != "[0 3 1 4]" {
+		t.Errorf("unexpected vertexes over both directions: %v", data(found))
+	}
+
+	found = graph.FindN(graph.DFS, 10, all, graph.MaxDepth(0)).Search(v0)
+	if fmt.Sprint(data(found)) != "[0]" {
+		t.Errorf("unexpected vertexes with zero depth: %v", data(found))
+	}
+
+	var order []int
+	for iterator := graph.DFS.StartAt(v1, graph.MaxDepth(2), graph.InDirection(graph.IncomingDirection)); iterator.HasNext(); {
+		order = append(order, iterator.Next().Data().(int))
+	}
+	if fmt.Sprint(order) != "[3 0 1]" {
+		t.Errorf("unexpected order of vertexes: %v", order)
+	}
+}
+
+func TestGraph_DFSOrders(t *testing.T) {
+	v4 := graph.VertexWith(4).
+		EdgeTo(graph.VertexWith(2)).
+		EdgeTo(graph.VertexWith(3))
+	v0 := graph.VertexWith(0).
+		EdgeTo(graph.VertexWith(1)).
+		EdgeTo(v4).
+		EdgeTo(graph.VertexWith(5))
+	v4.EdgeTo(v0)
+
+	expectedOrders := map[graph.SearchAlgorithm]string{
+		graph.DFSPreOrder:         "[0 1 4 2 3 5]",
+		graph.DFSPostOrder:        "[1 2 3 4 5 0]",
+		graph.DFSReversePostOrder: "[0 5 4 3 2 1]",
+	}
+	for algorithm, expected := range expectedOrders {
+		var order []int
+		for iterator := algorithm.StartAt(v0); iterator.HasNext(); {
+			order = append(order, iterator.Next().Data().(int))
+		}
+		if fmt.Sprint(order) != expected {
+			t.Errorf("algorithm %d: unexpected order of vertexes: %v", algorithm, order)
+		}
+	}
+
+	times := graph.DFSVisitTimes(v0)
+	if len(times) != 6 {
+		t.Fatalf("unexpected amount of visited vertexes: %d", len(times))
+	}
+	if times[v0].Discovered != 1 || times[v0].Finished != 12 {
+		t.Errorf("unexpected visit time of the start vertex: %+v", times[v0])
+	}
+	if times[v4].Discovered != 4 || times[v4].Finished != 9 {
+		t.Errorf("unexpected visit time of the vertex: %+v", times[v4])
+	}
+	for _, vtx := range v4.Outcoming().Vertexes() {
+		if vtx != v0 && (times[vtx].Discovered < times[v4].Discovered || times[vtx].Finished > times[v4].Finished) {
+			t.Errorf("descendant must be visited within its ancestor: %+v", times[vtx])
+		}
+	}
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	g := graph.NewGraph()
+	app := g.AddVertex("app")
+	lib := g.AddVertex("lib")
+	util := g.AddVertex("util")
+	log := g.AddVertex("log")
+	text := g.AddVertex("text")
+	app.EdgeTo(lib).EdgeTo(log)
+	lib.EdgeTo(util).EdgeTo(text)
+	log.EdgeTo(text)
+	util.EdgeTo(text)
+
+	names := func(vtxs []*graph.Vertex) (res string) {
+		for _, vtx := range vtxs {
+			res += vtx.Data().(string) + " "
+		}
+		return
+	}
+
+	expected := map[graph.TopologicalAlgorithm]string{
+		graph.Kahn:       "app lib util log text ",
+		graph.DepthFirst: "app log lib util text ",
+	}
+	for algorithm, order := range expected {
+		sorted, err := g.TopologicalSort(algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names(sorted) != order {
+			t.Errorf("algorithm %d: unexpected order: %s", algorithm, names(sorted))
+		}
+
+		sorted, err = lib.TopologicalSort(algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names(sorted) != "lib util text " {
+			t.Errorf("algorithm %d: unexpected order of reachable vertexes: %s", algorithm, names(sorted))
+		}
+	}
+
+	text.EdgeTo(lib)
+	for _, algorithm := range []graph.TopologicalAlgorithm{graph.Kahn, graph.DepthFirst} {
+		_, err := g.TopologicalSort(algorithm)
+		cycleErr, ok := err.(*graph.CycleError)
+		if !ok {
+			t.Fatalf("algorithm %d: unexpected error: %v", algorithm, err)
+		}
+		cycle := cycleErr.Cycle
+		for i, vtx := range cycle {
+			if vtx.EdgesTo(cycle[(i+1)%len(cycle)]).Len() == 0 {
+				t.Errorf("algorithm %d: not a cycle: %s", algorithm, names(cycle))
+			}
+		}
+		if len(cycle) < 2 {
+			t.Errorf("algorithm %d: unexpected cycle: %s", algorithm, names(cycle))
+		}
+	}
+}
+
+func TestGraph_FindCycles(t *testing.T) {
+	const (
+		product = "product"
+		metric  = "metric"
+	)
+	g := graph.NewGraph()
+	coke := g.AddVertex("coke")
+	pepsi := g.AddVertex("pepsi")
+	units := g.AddVertex("units")
+	sales := g.AddVertex("sales")
+	coke.EdgeToWith(units, metric).EdgeToWith(sales, metric)
+	units.EdgeToWith(coke, product).EdgeToWith(pepsi, product)
+	pepsi.EdgeToWith(sales, metric)
+	sales.EdgeToWith(coke, product)
+	sales.EdgeToWith(sales, "self")
+	pepsi.Edge(g.AddVertex("juice"))
+
+	route := func(path graph.Path) (res string) {
+		for _, vtx := range path.Vertexes() {
+			res += "/" + vtx.Data().(string)
+		}
+		return
+	}
+	routes := func(paths []graph.Path) (res []string) {
+		for _, path := range paths {
+			if path.Len() != len(path.Vertexes())-1 || path.Start() != path.End() {
+				t.Errorf("not a cycle: %s", route(path))
+			}
+			res = append(res, route(path))
+		}
+		return
+	}
+
+	all := routes(graph.FindCycles(g.Vertexes(), nil, 0))
+	expected := "[/coke/units/coke /coke/units/pepsi/sales/coke /coke/sales/coke /sales/sales]"
+	if fmt.Sprint(all) != expected {
+		t.Errorf("unexpected cycles: %v", all)
+	}
+
+	productMetric := func(edge *graph.Edge) bool {
+		return edge.Attributes() == product || edge.Attributes() == metric
+	}
+	if found := routes(graph.FindCycles(g.Vertexes(), productMetric, 2)); len(found) != 2 {
+		t.Errorf("unexpected amount of cycles: %v", found)
+	}
+	if found := routes(units.FindCycles(productMetric, 0)); fmt.Sprint(found) != "[/units/coke/units /units/pepsi/sales/coke/units]" {
+		t.Errorf("unexpected cycles through the vertex: %v", found)
+	}
+	if !sales.HasCycle(nil) || sales.HasCycle(graph.EdgeAttributeEqualsTo(product)) {
+		t.Error("unexpected cycle through the vertex")
+	}
+	if pepsi.HasCycle(graph.EdgeAttributeEqualsTo(nil)) {
+		t.Error("cycle can't go back over the same undirected edge")
+	}
+
+	undirected := graph.NewGraph()
+	a := undirected.AddVertex("a")
+	b := undirected.AddVertex("b")
+	c := undirected.AddVertex("c")
+	d := undirected.AddVertex("d")
+	a.Edge(b).Edge(c)
+	b.Edge(c)
+	c.EdgeTo(d)
+	d.Edge(a)
+	if found := routes(graph.FindCycles(undirected.Vertexes(), nil, 0)); fmt.Sprint(found) != "[/a/b/c/a /a/b/c/d/a /a/c/d/a]" {
+		t.Errorf("unexpected cycles over undirected edges: %v", found)
+	}
+	if found := routes(c.FindCycles(nil, 0)); len(found) != 3 {
+		t.Errorf("unexpected cycles through the vertex over undirected edges: %v", found)
+	}
+}
+
+func TestGraph_Components(t *testing.T) {
+	g := graph.NewGraph()
+	vtxs := make([]*graph.Vertex, 8)
+	for i := range vtxs {
+		vtxs[i] = g.AddVertex(i)
+	}
+	vtxs[0].EdgeTo(vtxs[1])
+	vtxs[1].EdgeTo(vtxs[2]).EdgeTo(vtxs[4])
+	vtxs[2].EdgeTo(vtxs[0]).EdgeTo(vtxs[3])
+	vtxs[3].EdgeTo(vtxs[4])
+	vtxs[4].EdgeTo(vtxs[3])
+	vtxs[5].Edge(vtxs[6])
+	vtxs[6].EdgeTo(vtxs[7])
+
+	data := func(components []graph.VertexSet) (res [][]int) {
+		for _, component := range components {
+			var cdata []int
+			for iterator := component.Iterator(); iterator.HasNext(); {
+				cdata = append(cdata, iterator.Next().Data().(int))
+			}
+			res = append(res, cdata)
+		}
+		return
+	}
+
+	for _, algorithm := range []graph.ComponentsAlgorithm{graph.Tarjan, graph.Kosaraju} {
+		components := data(graph.StronglyConnectedComponents(g.Vertexes(), algorithm))
+		if fmt.Sprint(components) != "[[0 1 2] [3 4] [5 6] [7]]" {
+			t.Errorf("algorithm %d: unexpected strongly connected components: %v", algorithm, components)
+		}
+	}
+
+	if components := data(graph.WeaklyConnectedComponents(g.Vertexes())); fmt.Sprint(components) != "[[0 1 2 3 4] [5 6 7]]" {
+		t.Errorf("unexpected weakly connected components: %v", components)
+	}
+
+	condensation := graph.Condensation(g.Vertexes())
+	if condensation.Order() != 4 || condensation.Size() != 2 {
+		t.Errorf("unexpected condensation: %d vertexes, %d edges", condensation.Order(), condensation.Size())
+	}
+	iterator := condensation.Vertexes().Iterator()
+	first := iterator.Next()
+	edges := first.Outcoming().Iterator()
+	edge := edges.Next()
+	if edge.Attributes().(graph.EdgeSet).Len() != 2 || edge.To().Data().(graph.VertexSet).Len() != 2 {
+		t.Errorf("unexpected edge between components: %d", edge.Attributes().(graph.EdgeSet).Len())
+	}
+	if _, err := condensation.TopologicalSort(graph.Kahn); err != nil {
+		t.Error("condensation must be acyclic:", err)
+	}
+}
+
+func TestGraph_Reachability(t *testing.T) {
+	const (
+		category = "category"
+		link     = "link"
+	)
+	root := graph.VertexWith("root")
+	drinks := graph.VertexWith("drinks")
+	soda := graph.VertexWith("soda")
+	juice := graph.VertexWith("juice")
+	food := graph.VertexWith("food")
+	root.EdgeToWith(drinks, category).EdgeToWith(food, category)
+	drinks.EdgeToWith(soda, category).EdgeToWith(juice, category)
+	juice.EdgeToWith(root, link)
+
+	names := func(vs graph.VertexSet) (res []string) {
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			res = append(res, iterator.Next().Data().(string))
+		}
+		return
+	}
+
+	if descendants := names(root.Descendants(nil)); fmt.Sprint(descendants) != "[drinks food soda juice]" {
+		t.Errorf("unexpected descendants: %v", descendants)
+	}
+	if descendants := names(drinks.Descendants(graph.EdgeAttributeEqualsTo(category))); fmt.Sprint(descendants) != "[soda juice]" {
+		t.Errorf("unexpected descendants: %v", descendants)
+	}
+	if ancestors := names(soda.Ancestors(nil)); fmt.Sprint(ancestors) != "[drinks root juice]" {
+		t.Errorf("unexpected ancestors: %v", ancestors)
+	}
+	if ancestors := names(soda.Ancestors(graph.EdgeAttributeEqualsTo(category))); fmt.Sprint(ancestors) != "[drinks root]" {
+		t.Errorf("unexpected ancestors: %v", ancestors)
+	}
+
+	if !graph.Reachable(root, juice, nil) || !graph.Reachable(juice, food, nil) || !graph.Reachable(soda, soda, nil) {
+		t.Error("vertex must be reachable")
+	}
+	if graph.Reachable(juice, food, graph.EdgeAttributeEqualsTo(category)) || graph.Reachable(soda, root, nil) {
+		t.Error("vertex must not be reachable")
+	}
+}
+
+func TestGraph_LowestCommonAncestors(t *testing.T) {
+	company := graph.VertexWith("company")
+	sales := graph.VertexWith("sales")
+	engineering := graph.VertexWith("engineering")
+	backend := graph.VertexWith("backend")
+	frontend := graph.VertexWith("frontend")
+	platform := graph.VertexWith("platform")
+	company.EdgeTo(sales).EdgeTo(engineering)
+	engineering.EdgeTo(backend).EdgeTo(frontend)
+	backend.EdgeTo(platform)
+	frontend.EdgeTo(platform)
+
+	if lca := graph.LowestCommonAncestor(backend, frontend, nil); lca != engineering {
+		t.Errorf("unexpected lowest common ancestor: %v", lca.Data())
+	}
+	if lca := graph.LowestCommonAncestor(platform, sales, nil); lca != company {
+		t.Errorf("unexpected lowest common ancestor: %v", lca.Data())
+	}
+	if lca := graph.LowestCommonAncestor(platform, engineering, nil); lca != engineering {
+		t.Errorf("unexpected lowest common ancestor: %v", lca.Data())
+	}
+	if lca := graph.LowestCommonAncestor(sales, graph.VertexWith("other"), nil); lca != nil {
+		t.Errorf("unexpected lowest common ancestor: %v", lca.Data())
+	}
+
+	shared := graph.VertexWith("shared")
+	backend.EdgeTo(shared)
+	frontend.EdgeTo(shared)
+	lowest := graph.LowestCommonAncestors(platform, shared, nil)
+	if len(lowest) != 2 || lowest[0] != backend || lowest[1] != frontend {
+		t.Errorf("unexpected lowest common ancestors: %v", lowest)
+	}
+}
+
+func TestGraph_DominatorTree(t *testing.T) {
+	vtxs := make([]*graph.Vertex, 7)
+	for i := range vtxs {
+		vtxs[i] = graph.VertexWith(i)
+	}
+	vtxs[0].EdgeTo(vtxs[1])
+	vtxs[1].EdgeTo(vtxs[2]).EdgeTo(vtxs[3])
+	vtxs[2].EdgeTo(vtxs[4])
+	vtxs[3].EdgeTo(vtxs[4])
+	vtxs[4].EdgeTo(vtxs[5]).EdgeTo(vtxs[1])
+	vtxs[5].EdgeTo(vtxs[6])
+	graph.VertexWith(7).EdgeTo(vtxs[5])
+
+	dt := graph.DominatorTreeOf(vtxs[0])
+	expected := []int{-1, 0, 1, 1, 1, 4, 5}
+	for i, vtx := range vtxs {
+		idom := dt.ImmediateDominator(vtx)
+		if (idom == nil && expected[i] != -1) || (idom != nil && idom.Data().(int) != expected[i]) {
+			t.Errorf("unexpected immediate dominator of %d: %v", i, idom)
+		}
+	}
+	if dominated := dt.Dominated(vtxs[1]); len(dominated) != 3 {
+		t.Errorf("unexpected amount of dominated vertexes: %d", len(dominated))
+	}
+	if !dt.Dominates(vtxs[1], vtxs[6]) || !dt.Dominates(vtxs[0], vtxs[4]) || !dt.Dominates(vtxs[4], vtxs[4]) {
+		t.Error("vertex must be dominated")
+	}
+	if dt.Dominates(vtxs[2], vtxs[4]) || dt.Dominates(vtxs[0], graph.VertexWith(8)) {
+		t.Error("vertex must not be dominated")
+	}
+}
+
+func TestGraph_MinimumSpanningForest(t *testing.T) {
+	weight := func(edge *graph.Edge) float64 {
+		return edge.Attributes().(float64)
+	}
+	g := graph.NewGraph()
+	a := g.AddVertex("a")
+	b := g.AddVertex("b")
+	c := g.AddVertex("c")
+	d := g.AddVertex("d")
+	e := g.AddVertex("e")
+	a.EdgeWith(b, 4.0).EdgeWith(c, 1.0)
+	b.EdgeWith(c, 2.0).EdgeWith(d, 5.0)
+	c.EdgeWith(d, 8.0)
+	d.EdgeWith(d, 0.0)
+	x := g.AddVertex("x")
+	x.EdgeWith(g.AddVertex("y"), 3.0).EdgeWith(g.AddVertex("z"), 1.0)
+	e.EdgeTo(a)
+
+	for _, algorithm := range []graph.SpanningAlgorithm{graph.Kruskal, graph.Prim} {
+		forest := graph.MinimumSpanningForest(g.Vertexes(), algorithm, func(edge *graph.Edge) float64 {
+			if edge.Directed() {
+				return 10
+			}
+			return weight(edge)
+		})
+		if forest.Len() != 6 {
+			t.Errorf("algorithm %d: unexpected amount of edges: %d", algorithm, forest.Len())
+		}
+		var total float64
+		for iterator := forest.Iterator(); iterator.HasNext(); {
+			edge := iterator.Next()
+			if edge.Directed() {
+				total += 10
+			} else {
+				total += weight(edge)
+			}
+		}
+		if total != 22 {
+			t.Errorf("algorithm %d: unexpected total weight: %v", algorithm, total)
+		}
+
+		var path []string
+		err := d.TraversePathsWith(graph.FindFirst(graph.BFS, func(vtx *graph.Vertex) bool {
+			return vtx == a
+		}, graph.OverEdges(forest.Contains)), func(p graph.Path) error {
+			for _, vtx := range p.Vertexes() {
+				path = append(path, vtx.Data().(string))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(path) != "[d b c a]" {
+			t.Errorf("algorithm %d: unexpected path over the tree: %v", algorithm, path)
+		}
+	}
+}
+
+func TestGraph_MaxFlow(t *testing.T) {
+	capacity := func(edge *graph.Edge) float64 {
+		return edge.Attributes().(float64)
+	}
+	g := graph.NewGraph()
+	source := g.AddVertex("s")
+	v1 := g.AddVertex("v1")
+	v2 := g.AddVertex("v2")
+	v3 := g.AddVertex("v3")
+	v4 := g.AddVertex("v4")
+	sink := g.AddVertex("t")
+	source.EdgeToWith(v1, 16.0).EdgeToWith(v2, 13.0)
+	v1.EdgeToWith(v3, 12.0)
+	v2.EdgeToWith(v1, 4.0).EdgeToWith(v4, 14.0)
+	v3.EdgeToWith(v2, 9.0).EdgeToWith(sink, 20.0)
+	v4.EdgeToWith(v3, 7.0).EdgeToWith(sink, 4.0)
+
+	names := func(vs graph.VertexSet) (res []string) {
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			res = append(res, iterator.Next().Data().(string))
+		}
+		return
+	}
+
+	for _, algorithm := range []graph.FlowAlgorithm{graph.EdmondsKarp, graph.Dinic} {
+		flow := graph.MaxFlow(source, sink, algorithm, capacity)
+		if flow.Value() != 23 {
+			t.Errorf("algorithm %d: unexpected flow: %v", algorithm, flow.Value())
+		}
+		for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+			vtx := iterator.Next()
+			var balance float64
+			for edges := vtx.Outcoming().Iterator(); edges.HasNext(); {
+				edge := edges.Next()
+				if flow.Of(edge) < 0 || flow.Of(edge) > capacity(edge) {
+					t.Errorf("algorithm %d: flow %v exceeds capacity of the edge", algorithm, flow.Of(edge))
+				}
+				balance -= flow.Of(edge)
+			}
+			for edges := vtx.Incoming().Iterator(); edges.HasNext(); {
+				balance += flow.Of(edges.Next())
+			}
+			if vtx != source && vtx != sink && balance != 0 {
+				t.Errorf("algorithm %d: flow is not balanced in %v: %v", algorithm, vtx.Data(), balance)
+			}
+		}
+		if cut := names(flow.SourceSide()); fmt.Sprint(cut) != "[s v1 v2 v4]" {
+			t.Errorf("algorithm %d: unexpected source side of the cut: %v", algorithm, cut)
+		}
+		if cut := names(flow.SinkSide()); fmt.Sprint(cut) != "[v3 t]" {
+			t.Errorf("algorithm %d: unexpected sink side of the cut: %v", algorithm, cut)
+		}
+		if same := graph.MaxFlow(source, source, algorithm, capacity); same.Value() != 0 || same.SourceSide().Len() != 0 {
+			t.Errorf("algorithm %d: unexpected flow from the source to itself: %v", algorithm, same.Value())
+		}
+	}
+
+	a := graph.VertexWith("a")
+	b := graph.VertexWith("b")
+	c := graph.VertexWith("c")
+	a.EdgeWith(b, 3.0).EdgeToWith(c, 1.0)
+	c.EdgeToWith(b, 5.0)
+	flow := graph.MaxFlow(b, a, graph.Dinic, capacity)
+	if flow.Value() != 3 {
+		t.Errorf("unexpected flow over undirected edge: %v", flow.Value())
+	}
+	undirected := a.Outcoming().Iterator()
+	if edge := undirected.Next(); flow.Of(edge) != -3 {
+		t.Errorf("unexpected flow over undirected edge: %v", flow.Of(edge))
+	}
+	if flow := graph.MaxFlow(a, graph.VertexWith("d"), graph.EdmondsKarp, capacity); flow.Value() != 0 {
+		t.Errorf("unexpected flow to unreachable vertex: %v", flow.Value())
+	}
+}
+
+func TestGraph_Bipartition(t *testing.T) {
+	g := graph.NewGraph()
+	alice := g.AddVertex("alice")
+	bob := g.AddVertex("bob")
+	carol := g.AddVertex("carol")
+	billing := g.AddVertex("billing")
+	search := g.AddVertex("search")
+	mobile := g.AddVertex("mobile")
+	alice.EdgeWith(billing, 3.0).EdgeWith(search, 2.0)
+	bob.EdgeWith(billing, 4.0)
+	carol.EdgeWith(search, 1.0).EdgeWith(mobile, 1.0)
+	dave := g.AddVertex("dave")
+
+	names := func(vs graph.VertexSet) (res []string) {
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			res = append(res, iterator.Next().Data().(string))
+		}
+		return
+	}
+
+	left, right, err := graph.Bipartition(g.Vertexes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names(left)) != "[alice bob carol dave]" || fmt.Sprint(names(right)) != "[billing search mobile]" {
+		t.Errorf("unexpected parts: %v, %v", names(left), names(right))
+	}
+
+	matching, err := graph.MaximumMatching(g.Vertexes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matching.Len() != 3 {
+		t.Errorf("unexpected size of matching: %d", matching.Len())
+	}
+	matched := map[*graph.Vertex]bool{}
+	for iterator := matching.Iterator(); iterator.HasNext(); {
+		edge := iterator.Next()
+		if matched[edge.From()] || matched[edge.To()] {
+			t.Error("edges of matching must not share vertexes")
+		}
+		matched[edge.From()], matched[edge.To()] = true, true
+	}
+
+	weighted, err := graph.MaximumWeightMatching(g.Vertexes(), func(edge *graph.Edge) float64 {
+		return edge.Attributes().(float64)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total float64
+	for iterator := weighted.Iterator(); iterator.HasNext(); {
+		total += iterator.Next().Attributes().(float64)
+	}
+	if weighted.Len() != 3 || total != 7 {
+		t.Errorf("unexpected weighted matching of %d edges with weight %v", weighted.Len(), total)
+	}
+
+	dave.Edge(alice).Edge(billing)
+	_, _, err = graph.Bipartition(g.Vertexes())
+	oddCycleErr, ok := err.(*graph.OddCycleError)
+	if !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cycle := oddCycleErr.Cycle
+	if cycle.Len() != 3 || cycle.Start() != cycle.End() || len(cycle.Vertexes()) != 4 {
+		t.Errorf("unexpected odd cycle of %d edges", cycle.Len())
+	}
+	for i, edge := range cycle.Edges() {
+		if edge.Other(cycle.Vertexes()[i]) != cycle.Vertexes()[i+1] {
+			t.Error("edges of the cycle must connect its vertexes")
+		}
+	}
+	if _, err := graph.MaximumMatching(g.Vertexes()); err == nil {
+		t.Error("matching must fail for not bipartite graph")
+	}
+}
+
+func TestGraph_Centrality(t *testing.T) {
+	g := graph.NewGraph()
+	hub := g.AddVertex("hub")
+	for _, name := range []string{"x", "y", "z"} {
+		hub.EdgeWith(g.AddVertex(name), nil)
+	}
+	isolated := g.AddVertex("isolated")
+
+	ranking := func(scores graph.Scores) (res []string) {
+		for _, ranked := range scores.Ranked(g.Vertexes()) {
+			res = append(res, fmt.Sprintf("%s:%.2f", ranked.Vertex.Data(), ranked.Score))
+		}
+		return
+	}
+
+	if res := fmt.Sprint(ranking(graph.DegreeCentrality(g.Vertexes(), graph.BothDirections))); res != "[hub:3.00 x:1.00 y:1.00 z:1.00 isolated:0.00]" {
+		t.Errorf("unexpected degrees: %s", res)
+	}
+	if res := fmt.Sprint(ranking(graph.BetweennessCentrality(g.Vertexes(), nil))); res != "[hub:6.00 x:0.00 y:0.00 z:0.00 isolated:0.00]" {
+		t.Errorf("unexpected betweenness: %s", res)
+	}
+	if res := fmt.Sprint(ranking(graph.ClosenessCentrality(g.Vertexes(), nil))); res != "[hub:0.75 x:0.45 y:0.45 z:0.45 isolated:0.00]" {
+		t.Errorf("unexpected closeness: %s", res)
+	}
+
+	eigenvector, err := graph.EigenvectorCentrality(g.Vertexes(), 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ranked := eigenvector.Ranked(g.Vertexes()); ranked[0].Vertex != hub || ranked[4].Vertex != isolated || ranked[1].Score != ranked[3].Score {
+		t.Errorf("unexpected eigenvector centrality: %s", ranking(eigenvector))
+	}
+
+	pageRank, err := graph.PageRank(g.Vertexes(), 0.85, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total float64
+	for _, score := range pageRank {
+		total += score
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("page ranks must sum to 1: %v", total)
+	}
+	if ranked := pageRank.Ranked(g.Vertexes()); ranked[0].Vertex != hub || ranked[4].Vertex != isolated {
+		t.Errorf("unexpected page rank: %s", ranking(pageRank))
+	}
+
+	leaving := g.AddVertex("leaving")
+	leaving.EdgeTo(isolated).EdgeTo(hub)
+	hub.EdgeTo(leaving)
+	subset := graph.NewVertexSet(hub, g.Vertexes().ContainsData(func(data interface{}) bool { return data == "x" }), leaving)
+	for i := 0; i < 2; i++ {
+		again, err := graph.PageRank(subset, 0.85, 1e-9)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total = 0
+		for iterator := subset.Iterator(); iterator.HasNext(); {
+			vtx := iterator.Next()
+			total += again[vtx]
+			if i > 0 && again[vtx] != pageRank[vtx] {
+				t.Errorf("page rank must not change between calls: %v instead of %v", again[vtx], pageRank[vtx])
+			}
+		}
+		if math.Abs(total-1) > 1e-6 || len(again) != 3 {
+			t.Errorf("page ranks of subset must sum to 1: %v", total)
+		}
+		pageRank = again
+	}
+	if empty, err := graph.PageRank(graph.NewVertexSet(), 0.85, 1e-9); err != nil || len(empty) != 0 {
+		t.Errorf("unexpected page rank of empty set: %v %v", empty, err)
+	}
+}
+
+func TestGraph_Communities(t *testing.T) {
+	g := graph.NewGraph()
+	vtxs := map[string]*graph.Vertex{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		vtxs[name] = g.AddVertex(name)
+	}
+	for _, pair := range []string{"ab", "bc", "ca", "de", "ef", "fd"} {
+		vtxs[pair[:1]].EdgeWith(vtxs[pair[1:]], 1.0)
+	}
+	vtxs["c"].EdgeTo(vtxs["d"])
+
+	communities := func(groups []graph.GroupedVertexes) (res []string) {
+		for _, group := range groups {
+			names := string(group.GroupKey) + ":"
+			for _, vtx := range group.Vertexes {
+				names += vtx.Data().(string)
+			}
+			res = append(res, names)
+		}
+		return
+	}
+
+	for algorithm, name := range map[graph.CommunityAlgorithm]string{graph.LabelPropagation: "LabelPropagation", graph.Louvain: "Louvain"} {
+		groups := graph.Communities(g.Vertexes(), algorithm, nil)
+		if res := fmt.Sprint(communities(groups)); res != "[0:abc 1:def 2:g]" {
+			t.Errorf("%s: unexpected communities: %s", name, res)
+		}
+		if modularity := graph.Modularity(g.Vertexes(), groups, nil); math.Abs(modularity-5.0/14) > 1e-9 {
+			t.Errorf("%s: unexpected modularity: %v", name, modularity)
+		}
+	}
+
+	weight := func(edge *graph.Edge) float64 {
+		if edge.From() == vtxs["c"] && edge.To() == vtxs["d"] {
+			return 10
+		}
+		return 1
+	}
+	if res := fmt.Sprint(communities(graph.Communities(g.Vertexes(), graph.Louvain, weight))); res != "[0:ab 1:cd 2:ef 3:g]" {
+		t.Errorf("unexpected weighted communities: %s", res)
+	}
+}
+
+func TestGraph_Coloring(t *testing.T) {
+	g := graph.NewGraph()
+	jobs := map[string]*graph.Vertex{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		jobs[name] = g.AddVertex(name)
+	}
+	for _, conflict := range []string{"ab", "bc", "cd", "de", "ea", "fa", "fb"} {
+		jobs[conflict[:1]].Edge(jobs[conflict[1:]])
+	}
+
+	names := func(vtxs []*graph.Vertex) (res string) {
+		for _, vtx := range vtxs {
+			res += vtx.Data().(string)
+		}
+		return
+	}
+	setNames := func(vs graph.VertexSet) string {
+		var vtxs []*graph.Vertex
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			vtxs = append(vtxs, iterator.Next())
+		}
+		return names(vtxs)
+	}
+
+	for algorithm, name := range map[graph.ColoringAlgorithm]string{graph.Greedy: "Greedy", graph.DSatur: "DSatur", graph.WelshPowell: "WelshPowell"} {
+		var colors []string
+		colored := map[*graph.Vertex]string{}
+		for _, group := range graph.Coloring(g.Vertexes(), algorithm) {
+			colors = append(colors, string(group.GroupKey)+":"+names(group.Vertexes))
+			for _, vtx := range group.Vertexes {
+				colored[vtx] = string(group.GroupKey)
+			}
+		}
+		if res := fmt.Sprint(colors); res != "[0:ac 1:bd 2:ef]" {
+			t.Errorf("%s: unexpected coloring: %s", name, res)
+		}
+		for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+			edge := iterator.Next()
+			if colored[edge.From()] == colored[edge.To()] {
+				t.Errorf("%s: conflicting jobs %s and %s have the same color", name, edge.From().Data(), edge.To().Data())
+			}
+		}
+	}
+
+	if res := setNames(graph.MaximalIndependentSet(g.Vertexes())); res != "cef" {
+		t.Errorf("unexpected independent set: %s", res)
+	}
+
+	var cliques []string
+	for _, clique := range graph.MaximalCliques(g.Vertexes()) {
+		cliques = append(cliques, setNames(clique))
+	}
+	sort.Strings(cliques)
+	if res := fmt.Sprint(cliques); res != "[abf ae bc cd de]" {
+		t.Errorf("unexpected cliques: %s", res)
+	}
+}
+
+func TestGraph_Concurrent(t *testing.T) {
+	const workers, vertexes = 8, 50
+	g := graph.NewConcurrentGraph()
+	root := g.AddVertex(-1)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			previous := root
+			for i := 0; i < vertexes; i++ {
+				vtx := g.AddVertex(w*vertexes + i)
+				previous.EdgeToWith(vtx, i)
+				vtx.EdgeTo(root)
+				previous = vtx
+			}
+		}(w)
+	}
+	for r := 0; r < workers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < vertexes; i++ {
+				for iterator := root.Outcoming().Iterator(); iterator.HasNext(); {
+					edge := iterator.Next()
+					_ = edge.Attributes()
+					edge.To().Outcoming().Len()
+				}
+				for iterator := graph.BFS.StartAt(root); iterator.HasNext(); {
+					iterator.Next().Incoming().Len()
+				}
+				g.Vertexes().Len()
+				g.Edges().Len()
+				root.Adjacent().Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if g.Order() != workers*vertexes+1 {
+		t.Errorf("unexpected order of graph: %d", g.Order())
+	}
+	if g.Size() != 2*workers*vertexes {
+		t.Errorf("unexpected size of graph: %d", g.Size())
+	}
+	if root.Incoming().Len() != workers*vertexes {
+		t.Errorf("unexpected amount of incoming edges: %d", root.Incoming().Len())
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+				vtx := iterator.Next()
+				if vtx.Data().(int)%workers != w {
+					continue
+				}
+				for edges := vtx.Outcoming().Iterator(); edges.HasNext(); {
+					edges.Next().SetAttributes(w)
+				}
+				vtx.RemoveEdgesTo(root)
+			}
+		}(w)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for iterator := graph.DFS.StartAt(root, graph.InDirection(graph.BothDirections)); iterator.HasNext(); {
+				vtx := iterator.Next()
+				vtx.GroupedBy(func(edge *graph.Edge) []byte {
+					return []byte(fmt.Sprint(edge.Attributes()))
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if root.Incoming().Len() != 0 || g.Size() != workers*vertexes {
+		t.Errorf("unexpected edges left: %d incoming, %d in graph", root.Incoming().Len(), g.Size())
+	}
+}
+
+func TestGraph_Snapshot(t *testing.T) {
+	g := graph.NewGraph()
+	a := g.AddVertex("a")
+	b := g.AddVertex("b")
+	c := g.AddVertex("c")
+	a.EdgeToWith(b, 1)
+	b.EdgeWith(c, 2)
+
+	snapshot := g.Snapshot()
+
+	ab := a.Outcoming().Iterator()
+	ab.Next().SetAttributes(10)
+	a.EdgeToWith(c, 3)
+	b.RemoveEdgesTo(c)
+	d := g.AddVertex("d")
+	c.Detach().EdgeTo(d)
+
+	names := func(iterator graph.GraphIterator) (res string) {
+		for iterator.HasNext() {
+			res += iterator.Next().Data().(string)
+		}
+		return
+	}
+
+	if g.Order() != 4 || g.Size() != 2 {
+		t.Errorf("unexpected graph of %d vertexes and %d edges", g.Order(), g.Size())
+	}
+	if snapshot.Order() != 3 || snapshot.Size() != 2 {
+		t.Errorf("unexpected snapshot of %d vertexes and %d edges", snapshot.Order(), snapshot.Size())
+	}
+	if snapshot.Vertex(d) != nil {
+		t.Error("vertex added after snapshot must not be in it")
+	}
+	sa := snapshot.Vertex(a)
+	if sa == nil || sa == a || sa.Data() != "a" || sa.Graph() != snapshot.Graph {
+		t.Fatal("unexpected vertex of snapshot")
+	}
+	if res := names(graph.BFS.StartAt(sa)); res != "abc" {
+		t.Errorf("unexpected vertexes reachable in snapshot: %s", res)
+	}
+	if res := names(graph.BFS.StartAt(a)); res != "ab" {
+		t.Errorf("unexpected vertexes reachable in graph: %s", res)
+	}
+	edges := sa.Outcoming().Iterator()
+	if edge := edges.Next(); edge.Attributes() != 1 || edge.To() != snapshot.Vertex(b) || sa.Outcoming().Len() != 1 {
+		t.Errorf("unexpected outcoming edges in snapshot: %d", sa.Outcoming().Len())
+	}
+	if sc := snapshot.Vertex(c); sc.Adjacent().Len() != 1 || sc.Incoming().Len() != 1 {
+		t.Error("detached vertex must keep its edges in snapshot")
+	}
+
+	lonely := g.AddVertex("lonely")
+	beforeDetach := g.Snapshot()
+	lonely.Detach().EdgeTo(graph.VertexWith("x"))
+	if res := names(graph.BFS.StartAt(beforeDetach.Vertex(lonely))); res != "lonely" {
+		t.Errorf("edges added after detaching must not be in snapshot: %s", res)
+	}
+	beforeDetach.Release()
+
+	later := g.Snapshot()
+	snapshot.Release()
+	a.RemoveEdgesTo(b)
+	if res := names(graph.BFS.StartAt(later.Vertex(a))); res != "ab" || a.Outcoming().Len() != 0 {
+		t.Errorf("unexpected vertexes reachable in later snapshot: %s", res)
+	}
+	if res := names(graph.BFS.StartAt(later.Vertex(c))); res != "cd" {
+		t.Errorf("unexpected vertexes reachable in later snapshot: %s", res)
+	}
+	later.Release()
+
+	for name, change := range map[string]func(){
+		"EdgeTo":        func() { sa.EdgeTo(snapshot.Vertex(b)) },
+		"RemoveEdge":    func() { sa.RemoveEdgesTo(snapshot.Vertex(b)) },
+		"Detach":        func() { sa.Detach() },
+		"SetAttributes": func() { edges := sa.Outcoming().Iterator(); edges.Next().SetAttributes(0) },
+		"AddVertex":     func() { snapshot.AddVertex("e") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s must panic for snapshot", name)
+				}
+			}()
+			change()
+		}()
+	}
+}
+
+func TestGraph_SnapshotAfterRelease(t *testing.T) {
+	g := graph.NewGraph()
+	x := g.AddVertex("x")
+	y := g.AddVertex("y")
+	x.EdgeToWith(y, 1)
+	snapshot := g.Snapshot()
+	snapshot.Release()
+
+	z := g.AddVertex("z")
+	x.EdgeTo(z)
+	y.EdgeTo(z)
+	edges := x.Outcoming().Iterator()
+	edges.Next().SetAttributes(2)
+	x.RemoveEdgesTo(y)
+
+	if snapshot.Order() != 2 || snapshot.Size() != 1 {
+		t.Errorf("unexpected released snapshot of %d vertexes and %d edges", snapshot.Order(), snapshot.Size())
+	}
+	if snapshot.Vertex(z) != nil {
+		t.Error("vertex added after snapshot must not be in it")
+	}
+	sx := snapshot.Vertex(x)
+	if sx == nil || sx.Outcoming().Len() != 1 {
+		t.Fatal("vertex of released snapshot must keep its edges")
+	}
+	edges = sx.Outcoming().Iterator()
+	if edge := edges.Next(); edge.Attributes() != 1 || edge.To() != snapshot.Vertex(y) || edge.To().Outcoming().Len() != 0 {
+		t.Error("unexpected edge of released snapshot")
+	}
+}
+
+func TestGraph_ConcurrentSnapshot(t *testing.T) {
+	const workers, vertexes = 4, 100
+	g := graph.NewConcurrentGraph()
+	root := g.AddVertex(0)
+	for i := 1; i < vertexes; i++ {
+		root.EdgeTo(g.AddVertex(i))
+	}
+	snapshot := g.Snapshot()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+				vtx := iterator.Next()
+				vtx.EdgeToWith(g.AddVertex(-1), vtx.Data())
+				root.RemoveEdgesTo(vtx)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				visited := 0
+				for iterator := graph.DFS.StartAt(snapshot.Vertex(root)); iterator.HasNext(); iterator.Next() {
+					visited++
+				}
+				if visited != vertexes {
+					t.Errorf("snapshot changed: %d vertexes visited", visited)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if snapshot.Order() != vertexes || snapshot.Size() != vertexes-1 || root.Outcoming().Len() == vertexes-1 {
+		t.Errorf("unexpected snapshot of %d vertexes and %d edges", snapshot.Order(), snapshot.Size())
+	}
+}
+
+func TestGraph_Subgraph(t *testing.T) {
+	a := graph.VertexWith("a")
+	b := graph.VertexWith("b")
+	c := graph.VertexWith("c")
+	a.EdgeToWith(b, 1)
+	b.EdgeWith(c, 2)
+	c.EdgeTo(a)
+
+	names := func(vs graph.VertexSet) (res string) {
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			res += iterator.Next().Data().(string)
+		}
+		return
+	}
+	edges := func(g *graph.Graph) (res []string) {
+		for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+			edge := iterator.Next()
+			res = append(res, fmt.Sprintf("%s-%s:%v:%v", edge.From().Data(), edge.To().Data(), edge.Directed(), edge.Attributes()))
+		}
+		return
+	}
+
+	ca := a.Clone()
+	if ca == a || ca.Data() != "a" || ca.Graph() != nil {
+		t.Fatal("unexpected copy of vertex")
+	}
+	if res := names(ca.Adjacent()) + names(ca.Outcoming().VertexesSet()); res != "bcb" {
+		t.Errorf("unexpected edges of copy: %s", res)
+	}
+	ca.RemoveEdgesTo(ca.Outcoming().Vertexes()[0])
+	ca.EdgeTo(graph.VertexWith("e"))
+	if res := names(a.Adjacent()) + names(a.Outcoming().VertexesSet()); res != "bcb" {
+		t.Errorf("changes of copy must not affect original: %s", res)
+	}
+
+	g := graph.NewGraph()
+	g.Add(a)
+	g.AddVertex("d")
+
+	clone := g.Clone()
+	if res := names(clone.Vertexes()); res != names(g.Vertexes()) || res != "abcd" {
+		t.Errorf("unexpected vertexes of clone: %s", res)
+	}
+	if res := fmt.Sprint(edges(clone)); res != fmt.Sprint(edges(g)) || res != "[a-b:true:1 b-c:false:2 c-a:true:<nil>]" {
+		t.Errorf("unexpected edges of clone: %s", res)
+	}
+	for iterator := clone.Vertexes().Iterator(); iterator.HasNext(); {
+		if g.Vertexes().Contains(iterator.Next()) {
+			t.Error("clone must not share vertexes with graph")
+		}
+	}
+
+	subgraph := graph.Subgraph(graph.NewVertexSet(c, b))
+	if names(subgraph.Vertexes()) != "cb" || fmt.Sprint(edges(subgraph)) != "[b-c:false:2]" {
+		t.Errorf("unexpected induced subgraph: %s %s", names(subgraph.Vertexes()), edges(subgraph))
+	}
+
+	directed := g.EdgeSubgraph(func(edge *graph.Edge) bool { return edge.Directed() })
+	if names(directed.Vertexes()) != "abc" || fmt.Sprint(edges(directed)) != "[a-b:true:1 c-a:true:<nil>]" {
+		t.Errorf("unexpected edge subgraph: %s %s", names(directed.Vertexes()), edges(directed))
+	}
+	vertexes := directed.Vertexes().Iterator()
+	vertexes.Next().Detach()
+	if g.Size() != 3 || a.Outcoming().Len() != 1 || a.Incoming().Len() != 1 {
+		t.Error("changes of subgraph must not affect graph")
+	}
+
+	ordered := graph.NewGraph()
+	x, y, z, w := ordered.AddVertex("x"), ordered.AddVertex("y"), ordered.AddVertex("z"), ordered.AddVertex("w")
+	x.EdgeTo(y)
+	z.EdgeTo(w)
+	x.EdgeTo(z)
+	name := func(data interface{}) string { return fmt.Sprint(data) }
+	for _, check := range []struct{ res, expected string }{
+		{describe(ordered.Clone(), name), "[x y z w] [x>y:<nil> z>w:<nil> x>z:<nil>]"},
+		{describe(ordered.EdgeSubgraph(func(*graph.Edge) bool { return true }), name), "[x y z w] [x>y:<nil> z>w:<nil> x>z:<nil>]"},
+		{describe(graph.Subgraph(graph.NewVertexSet(w, z, y, x)), name), "[w z y x] [x>y:<nil> z>w:<nil> x>z:<nil>]"},
+	} {
+		if check.res != check.expected {
+			t.Errorf("copies of edges must keep order of graph: %s instead of %s", check.res, check.expected)
+		}
+	}
+}
+
+func TestGraph_SetOperations(t *testing.T) {
+	a, b, c, d := graph.VertexWith("a"), graph.VertexWith("b"), graph.VertexWith("c"), graph.VertexWith("d")
+	names := func(vs graph.VertexSet) (res string) {
+		for iterator := vs.Iterator(); iterator.HasNext(); {
+			res += iterator.Next().Data().(string)
+		}
+		return
+	}
+	abc, bcd := graph.NewVertexSet(a, b, c), graph.NewVertexSet(d, c, b)
+	for res, expected := range map[string]string{
+		names(abc.Union(bcd)):               "abcd",
+		names(abc.Intersect(bcd)):           "bc",
+		names(abc.Minus(bcd)):               "a",
+		names(abc.SymmetricDifference(bcd)): "ad",
+	} {
+		if res != expected {
+			t.Errorf("unexpected set: %s instead of %s", res, expected)
+		}
+	}
+
+	one := graph.NewGraph()
+	oa, ob, oc := one.AddVertex("A"), one.AddVertex("B"), one.AddVertex("C")
+	one.AddVertex("D")
+	oa.EdgeToWith(ob, 1).EdgeToWith(ob, 3)
+	ob.EdgeWith(oc, 2)
+	another := graph.NewGraph()
+	aa, ab, ac, ad := another.AddVertex("a"), another.AddVertex("b"), another.AddVertex("c"), another.AddVertex("d")
+	aa.EdgeToWith(ab, 10)
+	ac.Edge(ab).EdgeTo(ad)
+	key := func(data interface{}) interface{} { return strings.ToLower(data.(string)) }
+
+	name := func(data interface{}) string { return fmt.Sprint(data) }
+	for _, check := range []struct{ res, expected string }{
+		{describe(graph.Union(one, another, key), name), "[A B C D] [A>B:1 A>B:3 B-C:2 C>D:<nil>]"},
+		{describe(graph.Intersection(one, another, key), name), "[A B C D] [A>B:1 B-C:2]"},
+		{describe(graph.Difference(one, another, key), name), "[A B C D] [A>B:1]"},
+		{describe(graph.SymmetricDifference(one, another, key), name), "[A B C D] [A>B:1 C>D:<nil>]"},
+		{describe(graph.Union(one, another, nil), name), "[A B C D a b c d] [A>B:1 A>B:3 B-C:2 a>b:10 c-b:<nil> c>d:<nil>]"},
+		{describe(graph.Complement(one, false), name), "[A B C D] [A-C:<nil> A-D:<nil> B-D:<nil> C-D:<nil>]"},
+	} {
+		if check.res != check.expected {
+			t.Errorf("unexpected graph %s instead of %s", check.res, check.expected)
+		}
+	}
+	if complement := graph.Complement(one, true); complement.Size() != 9 || complement.Order() != 4 {
+		t.Errorf("unexpected directed complement: %s", describe(complement, name))
+	}
+}
+
+func TestGraph_Transformations(t *testing.T) {
+	g := graph.NewGraph()
+	a, b, c := g.AddVertex("a"), g.AddVertex("b"), g.AddVertex("c")
+	g.AddVertex("d")
+	a.EdgeToWith(b, 1)
+	b.EdgeToWith(c, 2)
+	c.EdgeWith(a, 3)
+	edges := g.Edges().Iterator()
+	ab, bc, ca := edges.Next(), edges.Next(), edges.Next()
+
+	var names func(data interface{}) string
+	names = func(data interface{}) string {
+		switch data := data.(type) {
+		case *graph.Edge:
+			return names(data.From().Data()) + names(data.To().Data())
+		case *graph.Vertex:
+			return names(data.Data())
+		case graph.GroupedVertexes:
+			return string(data.GroupKey)
+		case graph.EdgeSet:
+			var res []string
+			for iterator := data.Iterator(); iterator.HasNext(); {
+				res = append(res, names(iterator.Next()))
+			}
+			return strings.Join(res, ",")
+		default:
+			return fmt.Sprint(data)
+		}
+	}
+
+	for _, check := range []struct{ res, expected string }{
+		{describe(g.Transpose(), names), "[a b c d] [b>a:1 c>b:2 c-a:3]"},
+		{describe(g.LineGraph(), names), "[ab bc ca] [ca>ab:a ab>bc:b bc>ca:c]"},
+		{describe(g.Contract(ab), names), "[a c d] [a>c:2 c-a:3]"},
+		{describe(g.Contract(ca), names), "[b c d] [c>b:1 b>c:2]"},
+	} {
+		if check.res != check.expected {
+			t.Errorf("unexpected graph %s instead of %s", check.res, check.expected)
+		}
+	}
+	if g.Size() != 3 || a.Outcoming().Len() != 2 || bc.From() != b {
+		t.Error("transformations must not change graph")
+	}
+
+	groups := g.Vertexes().GroupedBy(func(vtx *graph.Vertex) []byte {
+		if vtx == c {
+			return []byte("y")
+		}
+		return []byte("x")
+	})
+	quotient := graph.Quotient(groups)
+	if res := describe(quotient, names); res != "[x y] [x>y:ca,bc y>x:ca]" {
+		t.Errorf("unexpected quotient graph: %s", res)
+	}
+	if res := graph.BFS.StartAt(quotient.Vertexes().ContainsData(func(data interface{}) bool {
+		return string(data.(graph.GroupedVertexes).GroupKey) == "y"
+	})); !res.HasNext() {
+		t.Error("quotient graph must be traversable")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("contraction of edge of another graph must panic")
+		}
+	}()
+	graph.NewGraph().Contract(ab)
+}
+
+func TestGraph_SnapshotWhileIngesting(t *testing.T) {
+	const batches, batch = 20, 50
+	g := graph.NewConcurrentGraph()
+	root := g.AddVertex("root")
+	for i := 0; i < batch; i++ {
+		root.EdgeToWith(g.AddVertex(i), i)
+	}
+	snapshot := g.Snapshot()
+	defer snapshot.Release()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < batches*batch; i++ {
+			vtx := g.AddVertex(batch + i)
+			root.EdgeToWith(vtx, i)
+			vtx.EdgeToWith(root, i)
+		}
+	}()
+
+	for i := 0; i < batches; i++ {
+		sRoot := snapshot.Vertex(root)
+		var total int
+		for iterator := sRoot.Outcoming().Iterator(); iterator.HasNext(); {
+			edge := iterator.Next()
+			total += edge.Attributes().(int)
+			if edge.To().Outcoming().Len() != 0 {
+				t.Fatal("edges added after snapshot must not be visible")
+			}
+		}
+		if total != batch*(batch-1)/2 || snapshot.Order() != batch+1 || snapshot.Size() != batch {
+			t.Fatalf("snapshot changed while graph was ingesting: %d vertexes, %d edges", snapshot.Order(), snapshot.Size())
+		}
+	}
+	<-done
+	if g.Size() != batch+2*batches*batch {
+		t.Errorf("unexpected size of graph: %d", g.Size())
+	}
+}
+
+// describes vertexes and edges of the graph in their order, ends of directed edges are joined with `>`
+// and ends of undirected ones with `-`
+func describe(g *graph.Graph, name func(data interface{}) string) string {
+	var vertexes, edges []string
+	for iterator := g.Vertexes().Iterator(); iterator.HasNext(); {
+		vertexes = append(vertexes, name(iterator.Next().Data()))
+	}
+	for iterator := g.Edges().Iterator(); iterator.HasNext(); {
+		edge := iterator.Next()
+		separator := "-"
+		if edge.Directed() {
+			separator = ">"
+		}
+		edges = append(edges, name(edge.From().Data())+separator+name(edge.To().Data())+":"+name(edge.Attributes()))
+	}
+	return fmt.Sprint(vertexes, edges)
+}